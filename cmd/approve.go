@@ -16,6 +16,7 @@ import (
 
 	"github.com/gateplane-io/client-cli/internal/config"
 	"github.com/gateplane-io/client-cli/internal/service"
+	"github.com/gateplane-io/client-cli/internal/vault"
 
 	"github.com/gateplane-io/client-cli/pkg/models"
 
@@ -68,29 +69,47 @@ func runInteractiveApprove() error {
 		return wrapError("get current user", err)
 	}
 
+	selectedRequest, err := selectPendingRequestInteractively(client, currentUser, "approve")
+	if err != nil || selectedRequest == nil {
+		return err
+	}
+
+	// Confirm approval
+	confirmPrompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Approve request from %s on gate '%s'", currentUser.Entity.ID, selectedRequest.Gate.Path),
+		IsConfirm: true,
+	}
+
+	_, err = confirmPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("approval cancelled")
+	}
+
+	// Approve the request using the request ID
+	return approveRequest(nil, selectedRequest.OwnerID, selectedRequest.Gate.Path)
+}
+
+// selectPendingRequestInteractively collects the pending requests the current user
+// can act on across all gates and lets the user select one of them.
+// Returns a nil request if there is nothing to act on.
+func selectPendingRequestInteractively(client *vault.Client, currentUser *models.Self, action string) (*models.Request, error) {
 	gates, err := client.DiscoverGates()
 	if err != nil {
-		return wrapError("discover gates", err)
+		return nil, wrapError("discover gates", err)
 	}
 
 	if len(gates) == 0 {
-		return fmt.Errorf("no gates discovered")
+		return nil, fmt.Errorf("no gates discovered")
 	}
 
 	// Collect all pending requests across all gates
 	var allRequests []*models.Request
-	for _, gate := range gates {
-		// Use ListAllRequests and filter for pending ones
-		gateRequests, err := client.ListAllRequestsForGate(gate.Path)
-		if err != nil {
-			// Continue to next gate if this one fails
-			continue
-		}
-		// Filter for requests that can be approved by current user:
+	for _, scan := range scanGates(client, gates, vault.ScanOptions{List: true}) {
+		// Filter for requests that can be acted on by current user:
 		// - Must be pending (not approved, denied, expired, or active)
 		// - Must not be from the current user (can't approve own requests)
 		// - Current user must not have already voted on it
-		for _, req := range gateRequests {
+		for _, req := range scan.Requests {
 			if req.Status == base.Pending &&
 				req.OwnerID != currentUser.Entity.ID &&
 				!req.HaveApproved {
@@ -104,7 +123,7 @@ func runInteractiveApprove() error {
 		fmt.Println("  • Requests already approved by you are not shown")
 		fmt.Println("  • Your own requests are not shown")
 		fmt.Println("  • Only requests in 'pending' status are shown")
-		return nil
+		return nil, nil
 	}
 
 	// Create display items for requests
@@ -119,12 +138,22 @@ func runInteractiveApprove() error {
 		)
 	}
 
-	// Select request to approve
+	// Select request to act on
+	selectedIndex, err := selectRequestItem(fmt.Sprintf("Select request to %s", action), requestItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return allRequests[selectedIndex], nil
+}
+
+// selectRequestItem prompts for one of the display items of requests, searchable when there are many
+func selectRequestItem(label string, requestItems []string) (int, error) {
 	prompt := promptui.Select{
-		Label:             "Select request to approve",
+		Label:             label,
 		Items:             requestItems,
 		Size:              10,
-		StartInSearchMode: len(allRequests) > 10,
+		StartInSearchMode: len(requestItems) > 10,
 		Searcher: func(input string, index int) bool {
 			return strings.Contains(strings.ToLower(requestItems[index]), strings.ToLower(input))
 		},
@@ -138,24 +167,9 @@ func runInteractiveApprove() error {
 
 	selectedIndex, _, err := prompt.Run()
 	if err != nil {
-		return fmt.Errorf("request selection cancelled: %w", err)
+		return 0, fmt.Errorf("request selection cancelled: %w", err)
 	}
-
-	selectedRequest := allRequests[selectedIndex]
-
-	// Confirm approval
-	confirmPrompt := promptui.Prompt{
-		Label:     fmt.Sprintf("Approve request from %s on gate '%s'", currentUser.Entity.ID, selectedRequest.Gate.Path),
-		IsConfirm: true,
-	}
-
-	_, err = confirmPrompt.Run()
-	if err != nil {
-		return fmt.Errorf("approval cancelled")
-	}
-
-	// Approve the request using the request ID
-	return approveRequest(nil, selectedRequest.OwnerID, selectedRequest.Gate.Path)
+	return selectedIndex, nil
 }
 
 func approveRequest(cmd *cobra.Command, requestID string, gate string) error {
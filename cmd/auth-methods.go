@@ -0,0 +1,254 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	vault_api "github.com/hashicorp/vault/api"
+	"golang.org/x/term"
+)
+
+// Vault auth methods supported by 'auth login'
+const (
+	AuthMethodToken      = "token"
+	AuthMethodUserpass   = "userpass"
+	AuthMethodLDAP       = "ldap"
+	AuthMethodOIDC       = "oidc"
+	AuthMethodAppRole    = "approle"
+	AuthMethodJWT        = "jwt"
+	AuthMethodKubernetes = "kubernetes"
+)
+
+var authMethods = []string{
+	AuthMethodToken,
+	AuthMethodUserpass,
+	AuthMethodLDAP,
+	AuthMethodOIDC,
+	AuthMethodAppRole,
+	AuthMethodJWT,
+	AuthMethodKubernetes,
+}
+
+// The service account token mounted in Kubernetes pods
+const defaultKubernetesJWTFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// The callback port used by the Vault CLI, usually allowed in the redirect URIs of OIDC roles
+const defaultOIDCCallbackPort = "8250"
+
+// authLoginOptions holds the credentials and settings of the non-token auth methods
+type authLoginOptions struct {
+	Username     string
+	Role         string
+	RoleID       string
+	SecretIDFile string
+	JWTFile      string
+	CallbackPort string
+	SkipBrowser  bool
+	// Passcodes of the login MFA methods, in the order they are required
+	MFAPasscodes []string
+}
+
+// loginWithMethod authenticates against the auth method mounted at mountPath,
+// prompting for the credentials that were not provided and completing login MFA if required.
+// Returns the secret holding the new client token.
+func loginWithMethod(client *vault_api.Client, method, mountPath string, opts authLoginOptions) (*vault_api.Secret, error) {
+	if mountPath == "" {
+		mountPath = method
+	}
+	mountPath = strings.Trim(mountPath, "/")
+
+	var (
+		secret *vault_api.Secret
+		err    error
+	)
+
+	switch method {
+	case AuthMethodUserpass, AuthMethodLDAP:
+		secret, err = loginWithPassword(client, mountPath, opts)
+	case AuthMethodAppRole:
+		secret, err = loginWithAppRole(client, mountPath, opts)
+	case AuthMethodJWT:
+		secret, err = loginWithJWT(client, mountPath, opts.Role, opts.JWTFile)
+	case AuthMethodKubernetes:
+		if opts.Role == "" {
+			return nil, fmt.Errorf("--role is required for the %s auth method", method)
+		}
+		jwtFile := opts.JWTFile
+		if jwtFile == "" {
+			jwtFile = defaultKubernetesJWTFile
+		}
+		secret, err = loginWithJWT(client, mountPath, opts.Role, jwtFile)
+	case AuthMethodOIDC:
+		secret, err = loginWithOIDC(client, mountPath, opts)
+	default:
+		return nil, fmt.Errorf("unsupported auth method: %s. Must be one of: %s", method, strings.Join(authMethods, ", "))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if secret != nil && secret.Auth != nil && secret.Auth.MFARequirement != nil {
+		secret, err = validateLoginMFA(client, secret.Auth.MFARequirement, opts.MFAPasscodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, fmt.Errorf("no token returned by the %s auth method at %s", method, mountPath)
+	}
+	return secret, nil
+}
+
+// loginWithPassword authenticates with a username and password (userpass and LDAP)
+func loginWithPassword(client *vault_api.Client, mountPath string, opts authLoginOptions) (*vault_api.Secret, error) {
+	username := opts.Username
+	if username == "" {
+		fmt.Print("Enter username: ")
+		if _, err := fmt.Scanln(&username); err != nil {
+			return nil, wrapError("read username", err)
+		}
+	}
+
+	password, err := readSecretInput("Enter password: ")
+	if err != nil {
+		return nil, wrapError("read password", err)
+	}
+
+	path := fmt.Sprintf("auth/%s/login/%s", mountPath, username)
+	return client.Logical().Write(path, map[string]interface{}{
+		"password": password,
+	})
+}
+
+// loginWithAppRole authenticates with a role ID and an optional secret ID
+func loginWithAppRole(client *vault_api.Client, mountPath string, opts authLoginOptions) (*vault_api.Secret, error) {
+	roleID := opts.RoleID
+	if roleID == "" {
+		fmt.Print("Enter role ID: ")
+		if _, err := fmt.Scanln(&roleID); err != nil {
+			return nil, wrapError("read role ID", err)
+		}
+	}
+
+	var (
+		secretID string
+		err      error
+	)
+	if opts.SecretIDFile != "" {
+		secretID, err = readCredentialFile(opts.SecretIDFile)
+	} else {
+		secretID, err = readSecretInput("Enter secret ID (leave empty if not required): ")
+	}
+	if err != nil {
+		return nil, wrapError("read secret ID", err)
+	}
+
+	data := map[string]interface{}{"role_id": roleID}
+	if secretID != "" {
+		data["secret_id"] = secretID
+	}
+	return client.Logical().Write(fmt.Sprintf("auth/%s/login", mountPath), data)
+}
+
+// loginWithJWT authenticates with a JWT read from a file (JWT and Kubernetes).
+// An empty role uses the default role of the mount.
+func loginWithJWT(client *vault_api.Client, mountPath, role, jwtFile string) (*vault_api.Secret, error) {
+	if jwtFile == "" {
+		return nil, fmt.Errorf("--jwt-file is required for JWT login")
+	}
+	jwt, err := readCredentialFile(jwtFile)
+	if err != nil {
+		return nil, wrapError("read JWT", err)
+	}
+
+	data := map[string]interface{}{"jwt": jwt}
+	if role != "" {
+		data["role"] = role
+	}
+	return client.Logical().Write(fmt.Sprintf("auth/%s/login", mountPath), data)
+}
+
+// loginWithOIDC authenticates through the browser, with the OIDC provider redirecting back to a local callback server.
+// An empty role uses the default role of the mount.
+func loginWithOIDC(client *vault_api.Client, mountPath string, opts authLoginOptions) (*vault_api.Secret, error) {
+	port := opts.CallbackPort
+	if port == "" {
+		port = defaultOIDCCallbackPort
+	}
+	redirectURI := fmt.Sprintf("http://localhost:%s/oidc/callback", port)
+
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return nil, wrapError("generate nonce", err)
+	}
+	clientNonce := hex.EncodeToString(nonceBytes)
+
+	data := map[string]interface{}{
+		"redirect_uri": redirectURI,
+		"client_nonce": clientNonce,
+	}
+	if opts.Role != "" {
+		data["role"] = opts.Role
+	}
+
+	resp, err := client.Logical().Write(fmt.Sprintf("auth/%s/oidc/auth_url", mountPath), data)
+	if err != nil {
+		return nil, wrapError("get OIDC authorization URL", err)
+	}
+	var authURL string
+	if resp != nil && resp.Data != nil {
+		authURL, _ = resp.Data["auth_url"].(string)
+	}
+	if authURL == "" {
+		return nil, fmt.Errorf("no OIDC authorization URL returned. Check that %s is an allowed redirect URI of the role", redirectURI)
+	}
+
+	result, err := waitForOIDCCallback(authURL, port, !opts.SkipBrowser)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Logical().ReadWithData(fmt.Sprintf("auth/%s/oidc/callback", mountPath), map[string][]string{
+		"state":        {result.State},
+		"code":         {result.Code},
+		"client_nonce": {clientNonce},
+	})
+}
+
+// readSecretInput prompts for a value without echoing it
+func readSecretInput(prompt string) (string, error) {
+	fmt.Print(prompt)
+	input, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	return string(input), nil
+}
+
+// readCredentialFile reads a credential (JWT, secret ID) from a file, without surrounding whitespace
+func readCredentialFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	credential := strings.TrimSpace(string(data))
+	if credential == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return credential, nil
+}
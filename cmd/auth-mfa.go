@@ -0,0 +1,112 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	vault_api "github.com/hashicorp/vault/api"
+	"github.com/manifoldco/promptui"
+	"golang.org/x/term"
+)
+
+// validateLoginMFA completes a login that returned an 'mfa_requirement'.
+// Every MFA constraint is satisfied by one of its methods: TOTP-like methods take a passcode
+// (from passcodes, in order, or prompted), push methods block until the user approves on their device.
+func validateLoginMFA(client *vault_api.Client, requirement *vault_api.MFARequirement, passcodes []string) (*vault_api.Secret, error) {
+	payload := map[string]interface{}{}
+
+	for _, name := range slices.Sorted(maps.Keys(requirement.MFAConstraints)) {
+		constraint := requirement.MFAConstraints[name]
+		if constraint == nil || len(constraint.Any) == 0 {
+			continue
+		}
+
+		method, err := selectMFAMethod(name, constraint.Any, len(passcodes) > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if !method.UsesPasscode {
+			fmt.Printf("Approve the %s push notification to continue...\n", mfaMethodName(method))
+			payload[method.ID] = []string{}
+			continue
+		}
+
+		var passcode string
+		switch {
+		case len(passcodes) > 0:
+			passcode, passcodes = passcodes[0], passcodes[1:]
+		case term.IsTerminal(int(os.Stdin.Fd())):
+			passcode, err = readSecretInput(fmt.Sprintf("Enter %s passcode: ", mfaMethodName(method)))
+			if err != nil {
+				return nil, wrapError("read MFA passcode", err)
+			}
+		default:
+			return nil, fmt.Errorf("%s requires a passcode. Provide it with --mfa-passcode", mfaMethodName(method))
+		}
+		payload[method.ID] = []string{passcode}
+	}
+
+	secret, err := client.Sys().MFAValidate(requirement.MFARequestID, payload)
+	if err != nil {
+		return nil, wrapError("validate MFA", err)
+	}
+	return secret, nil
+}
+
+// selectMFAMethod picks the method that satisfies an MFA constraint.
+// Passcode methods are preferred when passcodes were given, otherwise the user chooses.
+func selectMFAMethod(constraint string, methods []*vault_api.MFAMethodID, havePasscode bool) (*vault_api.MFAMethodID, error) {
+	if len(methods) == 1 {
+		return methods[0], nil
+	}
+
+	if havePasscode {
+		for _, method := range methods {
+			if method.UsesPasscode {
+				return method, nil
+			}
+		}
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return methods[0], nil
+	}
+
+	items := make([]string, len(methods))
+	for i, method := range methods {
+		items[i] = mfaMethodName(method)
+	}
+
+	prompt := promptui.Select{
+		Label: fmt.Sprintf("Select MFA method for %s", constraint),
+		Items: items,
+		Size:  len(items),
+	}
+
+	selectedIndex, _, err := prompt.Run()
+	if err != nil {
+		return nil, fmt.Errorf("MFA method selection cancelled: %w", err)
+	}
+	return methods[selectedIndex], nil
+}
+
+// mfaMethodName describes an MFA method by its name (if set) and type
+func mfaMethodName(method *vault_api.MFAMethodID) string {
+	if method.Name != "" {
+		return fmt.Sprintf("%s (%s)", method.Name, method.Type)
+	}
+	return method.Type
+}
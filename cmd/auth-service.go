@@ -14,7 +14,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/gateplane-io/client-cli/internal/config"
@@ -205,46 +204,13 @@ func performOIDCLogin(client *vault_api.Client, clientID string, skipBrowser boo
 	authURL := config.AuthCodeURL("state", oauth2.S256ChallengeOption(verifier))
 
 	var authCode string
-	var authError error
-	var wg sync.WaitGroup
 
 	if !skipBrowser {
-		// Start callback server
-		server, serverCh := startCallbackServer("45450")
-		defer func() {
-			_ = server.Shutdown(context.Background())
-		}()
-
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			select {
-			case result := <-serverCh:
-				if result.Error != nil {
-					authError = result.Error
-				} else {
-					authCode = result.Code
-				}
-			case <-time.After(5 * time.Minute): // Timeout after 5 minutes
-				authError = fmt.Errorf("authentication timed out")
-			}
-		}()
-
-		fmt.Printf("Starting local callback server on port 45450...\n")
-		fmt.Printf("Opening browser for OIDC authentication...\n")
-		fmt.Printf("If browser doesn't open automatically, visit: %s\n", authURL)
-
-		if err := browser.OpenURL(authURL); err != nil {
-			fmt.Printf("Failed to open browser: %v\n", err)
-			fmt.Printf("Please visit the URL manually: %s\n", authURL)
-		}
-
-		fmt.Printf("Waiting for callback...\n")
-		wg.Wait()
-
-		if authError != nil {
-			return "", authError
+		result, err := waitForOIDCCallback(authURL, "45450", true)
+		if err != nil {
+			return "", err
 		}
+		authCode = result.Code
 	} else {
 		// Manual code input
 		fmt.Printf("Visit this URL in your browser: %s\n", authURL)
@@ -261,6 +227,36 @@ func performOIDCLogin(client *vault_api.Client, clientID string, skipBrowser boo
 	return exchangeCodeForToken(config, authCode, verifier)
 }
 
+// waitForOIDCCallback starts the local callback server, sends the user to the authorization URL
+// and waits for the OIDC provider to redirect back with the authorization code
+func waitForOIDCCallback(authURL, port string, openBrowser bool) (callbackResult, error) {
+	server, serverCh := startCallbackServer(port)
+	defer func() {
+		_ = server.Shutdown(context.Background())
+	}()
+
+	fmt.Printf("Starting local callback server on port %s...\n", port)
+	if openBrowser {
+		fmt.Printf("Opening browser for OIDC authentication...\n")
+		fmt.Printf("If browser doesn't open automatically, visit: %s\n", authURL)
+
+		if err := browser.OpenURL(authURL); err != nil {
+			fmt.Printf("Failed to open browser: %v\n", err)
+			fmt.Printf("Please visit the URL manually: %s\n", authURL)
+		}
+	} else {
+		fmt.Printf("Visit this URL in your browser: %s\n", authURL)
+	}
+
+	fmt.Printf("Waiting for callback...\n")
+	select {
+	case result := <-serverCh:
+		return result, result.Error
+	case <-time.After(5 * time.Minute): // Timeout after 5 minutes
+		return callbackResult{}, fmt.Errorf("authentication timed out")
+	}
+}
+
 type callbackResult struct {
 	Code  string
 	State string
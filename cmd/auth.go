@@ -13,9 +13,14 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/vault"
+	project_models "github.com/gateplane-io/client-cli/pkg/models"
 	vault_api "github.com/hashicorp/vault/api"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -31,6 +36,7 @@ func authCmd() *cobra.Command {
 	cmd.AddCommand(
 		authLoginCmd(),
 		authStatusCmd(),
+		authRenewCmd(),
 		authLogoutCmd(),
 		serviceCmd(),
 	)
@@ -43,29 +49,76 @@ func authLoginCmd() *cobra.Command {
 		inputAddr  string
 		namespace  string
 		inputToken string
+		method     string
+		mountPath  string
+		opts       authLoginOptions
 	)
 
 	cmd := &cobra.Command{
 		Use:     "login",
 		Aliases: []string{"signin"},
 		Short:   "Authenticate with Vault",
-		Long:    "Authenticate with Vault/OpenBao using a token",
+		Long: `Authenticate with Vault/OpenBao using a token or one of the auth methods:
+  token       Prompt for a token (default)
+  userpass    Username and password (--username)
+  ldap        LDAP username and password (--username)
+  oidc        Browser login through an OIDC provider (--role)
+  approle     Role ID and secret ID (--role-id, --secret-id-file)
+  jwt         JWT read from a file (--role, --jwt-file)
+  kubernetes  Service account token of the pod (--role, --jwt-file)
+
+Auth methods mounted at a non-default path are selected with --path.
+If login MFA is enforced, passcodes are prompted for (or taken from --mfa-passcode)
+and push notifications are waited on.`,
+		Example: `  gateplane auth login
+  gateplane auth login --method userpass --username alice
+  gateplane auth login --method oidc --path okta --role engineers
+  gateplane auth login --method approle --role-id $ROLE_ID --secret-id-file ./secret-id
+  gateplane auth login --method jwt --role ci --jwt-file $CI_JOB_JWT_FILE
+  gateplane auth login --method kubernetes --role gateplane
+  gateplane auth login --method userpass --username alice --mfa-passcode 123456`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := config.GetConfig()
 
-			// Get vault address
+			if method != AuthMethodToken && inputToken != "" {
+				return fmt.Errorf("--token can only be used with the %s auth method", AuthMethodToken)
+			}
+
+			// The stored token is being replaced, it is not worth renewing
+			tokenRenewChecked = true
+
+			// Get vault address, from the configuration (or VAULT_ADDR) unless given
+			explicitAddr := ""
 			if inputAddr == "" {
 				inputAddr = cfg.Vault.Address
 				if inputAddr == "" {
-					if addr := os.Getenv("VAULT_ADDR"); addr != "" {
-						inputAddr = addr
-					} else {
-						fmt.Print("Enter Vault address: ")
-						if _, err := fmt.Scanln(&inputAddr); err != nil {
-							return wrapError("read vault address", err)
-						}
+					fmt.Print("Enter Vault address: ")
+					if _, err := fmt.Scanln(&inputAddr); err != nil {
+						return wrapError("read vault address", err)
 					}
+					explicitAddr = inputAddr
+				}
+			} else {
+				explicitAddr = inputAddr
+			}
+
+			// Used for this login, saved once it succeeds
+			vaultNamespace = namespace
+
+			// Exchange the credentials of the auth method for a token
+			if method != AuthMethodToken {
+				vaultAddr = inputAddr
+				loginClient, err := createVaultClient()
+				if err != nil {
+					return wrapError("create vault client", err)
+				}
+				loginClient.VaultClient().ClearToken()
+
+				secret, err := loginWithMethod(loginClient.VaultClient(), method, mountPath, opts)
+				if err != nil {
+					return wrapError(fmt.Sprintf("%s login", method), err)
 				}
+				inputToken = secret.Auth.ClientToken
 			}
 
 			// Token-based authentication
@@ -82,9 +135,6 @@ func authLoginCmd() *cobra.Command {
 			// Update global config for client creation
 			vaultAddr = inputAddr
 			vaultToken = inputToken
-			if namespace != "" {
-				cfg.Vault.Namespace = namespace
-			}
 
 			// Test connection
 			client, err := createVaultClient()
@@ -98,15 +148,16 @@ func authLoginCmd() *cobra.Command {
 				return wrapError("authentication failed", err)
 			}
 
-			// Save config
-			cfg.Vault.Address = vaultAddr
-			cfg.Vault.Token = vaultToken
-			if namespace != "" {
-				cfg.Vault.Namespace = namespace
-			}
-			if err := config.SaveConfig(); err != nil {
+			// Save config, without the address and namespace of the environment
+			if err := config.SetVaultLogin(explicitAddr, namespace, vaultToken); err != nil {
 				return wrapError("save config", err)
 			}
+			if cfg.Vault.TokenHelper != "" {
+				helper := &vault.TokenHelper{Path: cfg.Vault.TokenHelper}
+				if err := helper.Store(vaultAddr, vaultToken); err != nil {
+					return wrapError("store token", err)
+				}
+			}
 
 			printAuthSuccessMessage(tokenInfo)
 
@@ -117,30 +168,61 @@ func authLoginCmd() *cobra.Command {
 	cmd.Flags().StringVar(&inputAddr, "address", "", "Vault address")
 	cmd.Flags().StringVar(&namespace, "namespace", "", "Vault namespace")
 	cmd.Flags().StringVar(&inputToken, "token", "", "Vault token (use with caution)")
+	cmd.Flags().StringVarP(&method, "method", "m", AuthMethodToken, fmt.Sprintf("Auth method (%s)", strings.Join(authMethods, ", ")))
+	cmd.Flags().StringVar(&mountPath, "path", "", "Mount path of the auth method (defaults to the method name)")
+	cmd.Flags().StringVar(&opts.Username, "username", "", "Username (userpass, ldap)")
+	cmd.Flags().StringVar(&opts.Role, "role", "", "Role to log in with (oidc, jwt, kubernetes)")
+	cmd.Flags().StringVar(&opts.RoleID, "role-id", "", "Role ID (approle)")
+	cmd.Flags().StringVar(&opts.SecretIDFile, "secret-id-file", "", "File containing the secret ID (approle)")
+	cmd.Flags().StringVar(&opts.JWTFile, "jwt-file", "", "File containing the JWT (jwt, kubernetes; defaults to the pod's service account token)")
+	cmd.Flags().StringVar(&opts.CallbackPort, "callback-port", defaultOIDCCallbackPort, "Port of the local OIDC callback server (oidc)")
+	cmd.Flags().BoolVar(&opts.SkipBrowser, "skip-browser", false, "Print the login URL instead of opening the browser (oidc)")
+	cmd.Flags().StringArrayVar(&opts.MFAPasscodes, "mfa-passcode", nil, "Passcode for login MFA (repeat for multiple MFA constraints)")
 
 	return cmd
 }
 
+// authStatus is the structured output of 'auth status'
+type authStatus struct {
+	Address       string                    `json:"address" yaml:"address"`
+	Namespace     string                    `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Authenticated bool                      `json:"authenticated" yaml:"authenticated"`
+	Token         *project_models.TokenInfo `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
 func authStatusCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:     "status",
 		Aliases: []string{"whoami"},
 		Short:   "Check authentication status",
+		Long:    "Check authentication status, including the TTL, expiry and renewability of the token",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg := config.GetConfig()
-
 			client, err := createVaultClient()
 			if err != nil {
 				return wrapError("create vault client", err)
 			}
 
-			fmt.Printf("Vault Address: %s\n", cfg.Vault.Address)
-			if cfg.Vault.Namespace != "" {
-				fmt.Printf("Namespace: %s\n", cfg.Vault.Namespace)
+			status := authStatus{
+				Address:   client.VaultClient().Address(),
+				Namespace: client.VaultClient().Namespace(),
+			}
+			tokenInfo, err := client.LookupToken()
+			if err == nil {
+				status.Authenticated = true
+				status.Token = tokenInfo
 			}
 
-			tokenInfo, err := client.VaultClient().Auth().Token().LookupSelf()
-			if err != nil {
+			format := getEffectiveOutputFormat()
+			if format == OutputFormatJSON || format == OutputFormatYAML {
+				return formatOutput(status, format)
+			}
+
+			fmt.Printf("Vault Address: %s\n", status.Address)
+			if status.Namespace != "" {
+				fmt.Printf("Namespace: %s\n", status.Namespace)
+			}
+
+			if !status.Authenticated {
 				printFailedMessage("Not authenticated")
 				return nil
 			}
@@ -152,23 +234,146 @@ func authStatusCmd() *cobra.Command {
 	}
 }
 
+func authRenewCmd() *cobra.Command {
+	var increment time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "renew",
+		Short: "Renew the Vault token",
+		Long: `Renew the Vault token, extending its TTL by the increment (or its default TTL).
+Vault may grant less than requested, e.g. when the token is close to its max TTL.`,
+		Example: `  gateplane auth renew
+  gateplane auth renew --increment 2h`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createVaultClient()
+			if err != nil {
+				return wrapError("create vault client", err)
+			}
+
+			tokenInfo, err := client.LookupToken()
+			if err != nil {
+				return wrapError("lookup token", err)
+			}
+			if !tokenInfo.Renewable {
+				return fmt.Errorf("the token is not renewable")
+			}
+
+			tokenInfo, err = client.RenewToken(increment)
+			if err != nil {
+				return wrapError("renew token", err)
+			}
+
+			format := getEffectiveOutputFormat()
+			if format == OutputFormatJSON || format == OutputFormatYAML {
+				return formatOutput(tokenInfo, format)
+			}
+
+			printSuccessMessage("Token renewed")
+			fmt.Printf("TTL: %s\n", formatTokenTTL(tokenInfo))
+			if increment > 0 && tokenInfo.TTLDuration() < increment-time.Minute {
+				fmt.Printf("Warning: Vault granted less than the requested increment of %s (max TTL reached?)\n", increment)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&increment, "increment", 0, "Requested TTL extension (e.g. 30m, 2h), defaults to the token's default TTL")
+
+	return cmd
+}
+
 func authLogoutCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		keepServerToken bool
+		removeVaultFile bool
+		keepService     bool
+	)
+
+	cmd := &cobra.Command{
 		Use:     "logout",
 		Aliases: []string{"signout"},
-		Short:   "Clear stored authentication",
+		Short:   "Revoke and clear stored authentication",
+		Long: `Revoke the Vault token in Vault, remove it from the configuration
+and log out of GatePlane Services. Every cleared credential is reported.`,
+		Example: `  gateplane auth logout
+  gateplane auth logout --remove-vault-token-file
+  gateplane auth logout --keep-server-token`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := config.GetConfig()
-			cfg.Vault.Token = ""
 
-			if err := config.SaveConfig(); err != nil {
+			// The token is about to be revoked, it is not worth renewing
+			tokenRenewChecked = true
+
+			client, err := createVaultClient()
+			switch {
+			case keepServerToken:
+				fmt.Println("Kept the token valid in Vault (--keep-server-token)")
+			case err != nil:
+				printFailedMessage("Token not revoked in Vault: %v", err)
+			case client.VaultClient().Token() == "":
+				fmt.Println("No Vault token to revoke")
+			default:
+				if err := client.RevokeToken(); err != nil {
+					printFailedMessage("Token not revoked in Vault: %v", err)
+				} else {
+					printSuccessMessage("Revoked the token in Vault")
+				}
+			}
+
+			cleared, err := config.ClearVaultToken()
+			if err != nil {
 				return wrapError("save config", err)
 			}
+			if cleared {
+				printSuccessMessage("Removed the token from %s", config.CredentialsFilePath())
+			}
+			if cfg.Vault.TokenHelper != "" {
+				helper := &vault.TokenHelper{Path: cfg.Vault.TokenHelper}
+				if err := helper.Erase(getVaultClientConfig().Address); err != nil {
+					printFailedMessage("Token not erased from the token helper: %v", err)
+				} else {
+					printSuccessMessage("Erased the token from the token helper %s", cfg.Vault.TokenHelper)
+				}
+			}
+
+			if removeVaultFile {
+				removed, err := config.RemoveVaultFile()
+				if err != nil {
+					return wrapError("remove vault token file", err)
+				}
+				if removed {
+					printSuccessMessage("Removed %s", config.VaultFilePath())
+				}
+			} else if _, err := config.ReadVaultFile(); err == nil {
+				// Otherwise the token in the file is loaded again on the next run
+				fmt.Printf("Kept %s (remove it with --remove-vault-token-file)\n", config.VaultFilePath())
+			}
+
+			if os.Getenv("VAULT_TOKEN") != "" {
+				fmt.Println("VAULT_TOKEN is still set in the environment")
+			}
+
+			if keepService {
+				fmt.Println("Kept the GatePlane Services session (--keep-service)")
+			} else if cfg.Service.JWT != "" {
+				if err := config.ClearServiceAuth(); err != nil {
+					return wrapError("clear service auth", err)
+				}
+				printSuccessMessage("Logged out from GatePlane Services")
+			}
 
 			fmt.Println("Logged out successfully")
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&keepServerToken, "keep-server-token", false, "Do not revoke the token in Vault, only clear it locally")
+	cmd.Flags().BoolVar(&removeVaultFile, "remove-vault-token-file", false, "Also remove ~/.vault-token (written by 'vault login')")
+	cmd.Flags().BoolVar(&keepService, "keep-service", false, "Stay logged in to GatePlane Services")
+
+	return cmd
 }
 
 // printAuthSuccessMessage prints authentication success with optional username
@@ -188,26 +393,42 @@ func printAuthSuccessMessage(tokenInfo *vault_api.Secret) {
 }
 
 // printTokenInfo prints token information in a formatted way
-func printTokenInfo(tokenInfo *vault_api.Secret) {
-	if tokenInfo == nil || tokenInfo.Data == nil {
+func printTokenInfo(tokenInfo *project_models.TokenInfo) {
+	if tokenInfo == nil {
 		return
 	}
 
-	if username, ok := tokenInfo.Data["display_name"].(string); ok && username != "" {
-		fmt.Printf("Authenticated as: %s\n", username)
+	if tokenInfo.DisplayName != "" {
+		fmt.Printf("Authenticated as: %s\n", tokenInfo.DisplayName)
+	}
+	if len(tokenInfo.Policies) > 0 {
+		fmt.Printf("Policies: %s\n", strings.Join(tokenInfo.Policies, ", "))
 	}
 
-	policies, ok := tokenInfo.Data["policies"].([]interface{})
-	if !ok {
-		return
+	fmt.Printf("TTL: %s\n", formatTokenTTL(tokenInfo))
+	fmt.Printf("Renewable: %s\n", formatYesNo(tokenInfo.Renewable))
+	fmt.Printf("Orphan: %s\n", formatYesNo(tokenInfo.Orphan))
+}
+
+// formatTokenTTL returns the remaining TTL and expiry of a token, highlighting tokens about to expire
+func formatTokenTTL(tokenInfo *project_models.TokenInfo) string {
+	if !tokenInfo.Expires() {
+		return "never expires"
+	}
+
+	ttl := tokenInfo.TTLDuration().String()
+	if tokenInfo.ExpireTime != nil {
+		ttl = fmt.Sprintf("%s (expires %s)", ttl, tokenInfo.ExpireTime.Local().Format("2006-01-02 15:04:05 MST"))
 	}
+	if tokenInfo.TTLDuration() <= vault.MinAutoRenewThreshold {
+		return color.YellowString(ttl)
+	}
+	return ttl
+}
 
-	fmt.Print("Policies: ")
-	for i, p := range policies {
-		if i > 0 {
-			fmt.Print(", ")
-		}
-		fmt.Print(p)
+func formatYesNo(value bool) string {
+	if value {
+		return "yes"
 	}
-	fmt.Println()
+	return "no"
 }
@@ -12,10 +12,11 @@ package main
 
 import (
 	"fmt"
-
-	"github.com/fatih/color"
+	"os"
 
 	"github.com/gateplane-io/client-cli/internal/service"
+	"github.com/gateplane-io/client-cli/internal/table"
+	"github.com/gateplane-io/client-cli/internal/vault"
 	"github.com/gateplane-io/client-cli/pkg/models"
 
 	base "github.com/gateplane-io/vault-plugins/pkg/models"
@@ -27,16 +28,39 @@ func claimCmd() *cobra.Command {
 	var (
 		interactive bool
 		gate        string
+		execute     bool
+		release     bool
 	)
 
 	cmd := &cobra.Command{
-		Use:     "claim [gate]",
+		Use:     "claim [gate] [--exec -- <command> [args...]]",
 		Aliases: []string{"c"},
 		Short:   "Claim approved access",
-		Long:    "Claim approved access",
-		Args:    cobra.MaximumNArgs(1),
+		Long: `Claim approved access.
+
+With --exec, the command after '--' runs with VAULT_TOKEN, VAULT_ADDR and VAULT_NAMESPACE
+set to the claimed access, and its exit code is passed through.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			gateArgs, _ := splitExecArgs(cmd, args)
+			if len(gateArgs) > 1 {
+				return fmt.Errorf("accepts at most 1 gate, received %d", len(gateArgs))
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			args, command := splitExecArgs(cmd, args)
+			if execute && len(command) == 0 {
+				return fmt.Errorf("--exec requires a command after '--'")
+			}
+			if !execute && len(command) > 0 {
+				return fmt.Errorf("arguments after '--' require --exec")
+			}
+			if release && !execute {
+				return fmt.Errorf("--release can only be used with --exec")
+			}
+
 			useInteractive := isInteractiveMode(interactive, len(args) > 0, gate != "")
+			format := getEffectiveOutputFormat()
 
 			// var claimableGates []*models.Gate
 
@@ -47,35 +71,14 @@ func claimCmd() *cobra.Command {
 
 			svcClient, err := createServiceClient()
 			if err != nil {
-				fmt.Println("Not authenticated with GatePlane Services (using Community Edition features)")
+				// Keep stdout evaluable by the shell
+				fmt.Fprintln(os.Stderr, "Not authenticated with GatePlane Services (using Community Edition features)")
 				svcClient = nil
 			}
 
 			if useInteractive {
-				// Discover all gates first
-				gates, err := client.DiscoverGates()
-				if err != nil {
-					return wrapError("discover gates", err)
-				}
-
-				// Get claimable requests from gates
-				var gateRequest *models.Request
-				var claimableGates []*models.Gate
-				for _, gate := range gates {
-					gateRequest, err = client.GetRequestStatus(gate.Path)
-					if err == nil && gateRequest != nil && gateRequest.Status == base.Approved {
-						claimableGates = append(claimableGates, gate)
-					}
-				}
-
-				// No Request can be claimed, do not proceed.
-				if len(claimableGates) == 0 {
-					color.New(color.Bold).Println("No Claimable Requests available.")
-					return nil
-				}
-
-				gate, err = selectGateInteractively(client, claimableGates)
-				if err != nil {
+				gate, err = selectClaimableGateInteractively(client)
+				if err != nil || gate == "" {
 					return err
 				}
 			} else {
@@ -85,46 +88,122 @@ func claimCmd() *cobra.Command {
 				}
 			}
 
-			req, err := client.GetRequestStatus(gate)
-			if err != nil {
-				return wrapError("get request status", err)
-			}
-
-			if req.Status != base.Approved {
-				return wrapError("claim access", fmt.Errorf("request is not approved (status: %s)", req.Status))
-			}
-
-			claimResponse, err := client.ClaimAccess(gate)
+			claimResponse, err := claimAccess(client, svcClient, gate)
 			if err != nil {
-				return wrapError("claim access", err)
+				return err
 			}
 
-			// Send notification if service is authenticated
-			if err := sendNotificationWithRetry(svcClient, client, req, gate, service.Claim); err != nil {
-				return wrapError("send notification", err)
+			if execute {
+				return runWithClaimedAccess(cmd, client, svcClient, gate, claimResponse, command, release)
 			}
 
-			format := getEffectiveOutputFormat()
 			switch format {
 			case OutputFormatJSON, OutputFormatYAML:
 				return formatOutput(claimResponse, format)
 
+			case OutputFormatEnv:
+				return formatOutput(claimEnvVars(client, claimResponse), format)
+
 			default: // table
 				printSuccessMessage("Access claimed successfully on gate: %s", gate)
 			}
 
-			accessStruct, err := client.GetPolicyGateAccessStruct(gate)
-			if err == nil {
-				fmt.Println("Claimed Access:")
-				renderAccessTable(*accessStruct)
-			} else {
-				fmt.Printf("Warning: failed to get gate access struct for notification: %v\n", err)
+			gateType, err := client.GetGateType(gate)
+			if err != nil {
+				fmt.Printf("Warning: failed to determine gate type: %v\n", err)
+				return nil
 			}
 
+			renderClaimDetails(gateType, claimResponse)
+			renderGateAccess(client, gate, gateType, "Claimed Access:")
+
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().BoolVar(&execute, "exec", false, "Run the command after '--' with the claimed credentials")
+	cmd.Flags().BoolVar(&release, "release", false, "Release the claimed access when the command exits (with --exec)")
 	return cmd
 }
+
+// selectClaimableGateInteractively lets the user pick one of the gates with an Approved request.
+// Returns an empty gate if there is nothing to claim.
+func selectClaimableGateInteractively(client *vault.Client) (string, error) {
+	// Discover all gates first
+	gates, err := client.DiscoverGates()
+	if err != nil {
+		return "", wrapError("discover gates", err)
+	}
+
+	// Get claimable requests from gates
+	return selectOwnRequestGateInteractively(client, gates, "No Claimable Requests available.", base.Approved)
+}
+
+// claimAccess claims the approved request of the caller on the gate and notifies the approvers
+func claimAccess(client *vault.Client, svcClient *service.Client, gate string) (map[string]interface{}, error) {
+	req, err := client.GetRequestStatus(gate)
+	if err != nil {
+		return nil, wrapError("get request status", err)
+	}
+
+	if req == nil {
+		return nil, wrapError("claim access", fmt.Errorf("no request found on gate %s", gate))
+	}
+
+	if req.Status != base.Approved {
+		return nil, wrapError("claim access", fmt.Errorf("request is not approved (status: %s)", req.Status))
+	}
+
+	claimResponse, err := client.ClaimAccess(gate)
+	if err != nil {
+		return nil, wrapError("claim access", err)
+	}
+
+	// Send notification if service is authenticated
+	if err := sendNotificationWithRetry(svcClient, client, req, gate, service.Claim); err != nil {
+		return nil, wrapError("send notification", err)
+	}
+
+	return claimResponse, nil
+}
+
+// renderClaimDetails displays the claim response fields of the gate type
+func renderClaimDetails(gateType models.GateType, claimResponse map[string]interface{}) {
+	def, ok := gateType.Definition()
+	if !ok || def.RenderClaim == nil {
+		return
+	}
+
+	fields := def.RenderClaim(claimResponse)
+	if len(fields) == 0 {
+		return
+	}
+
+	rows := make([]table.Row, 0, len(fields))
+	for _, field := range fields {
+		rows = append(rows, table.Row{field.Name, field.Value})
+	}
+
+	table.RenderTable(table.TableOptions{
+		Headers: []string{"Claim", "Value"},
+		SortBy:  -1,
+		GroupBy: -1,
+	}, rows)
+}
+
+// claimEnvVars returns the environment that uses the claimed access.
+// Gates that issue a dedicated token return it in the claim response,
+// otherwise the claimed access is attached to the current token.
+func claimEnvVars(client *vault.Client, claimResponse map[string]interface{}) []envVar {
+	token := client.VaultClient().Token()
+	if claimedToken, ok := claimResponse["token"].(string); ok && claimedToken != "" {
+		token = claimedToken
+	}
+
+	return connectionEnvVars(
+		client.VaultClient().Address(),
+		client.VaultClient().Namespace(),
+		token,
+	)
+}
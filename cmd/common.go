@@ -13,7 +13,9 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/gateplane-io/client-cli/internal/config"
@@ -24,6 +26,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/gateplane-io/vault-plugins/pkg/models"
+	vault_api "github.com/hashicorp/vault/api"
 	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
 )
@@ -41,16 +44,50 @@ func getEffectiveOutputFormat() string {
 	if outputFormat != "" {
 		return outputFormat
 	}
-	cfg := config.GetConfig()
-	if cfg.Defaults.OutputFormat != "" {
+	// The configuration is nil if it cannot be read, e.g. for 'config validate'
+	if cfg := config.GetConfig(); cfg != nil && cfg.Defaults.OutputFormat != "" {
 		return cfg.Defaults.OutputFormat
 	}
 	return OutputFormatTable
 }
 
-// createVaultClient creates a vault client using the global configuration
+// tokenRenewChecked is set once the token has been checked for renewal during this command
+var tokenRenewChecked bool
+
+// createVaultClient creates a vault client using the global configuration.
+// The first client of a command also renews the token if it is renewable and about to expire.
 func createVaultClient() (*vault.Client, error) {
-	return vault.NewClient(getVaultClientConfig())
+	client, err := vault.NewClient(getVaultClientConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	if !tokenRenewChecked {
+		tokenRenewChecked = true
+		// Lookup failures (e.g. not authenticated) are reported by the command itself
+		if renewed, err := client.RenewTokenIfExpiring(); err == nil && renewed != nil {
+			fmt.Fprintf(os.Stderr, "Renewed Vault token, now valid for %s\n", renewed.TTLDuration())
+		}
+	}
+
+	return client, nil
+}
+
+// scanGates reads the requests of the gates in parallel and reports the gates that could not be read.
+// Permission errors are expected (e.g. listing requests on gates the user does not approve) and are not reported.
+func scanGates(client *vault.Client, gates []*project_models.Gate, opts vault.ScanOptions) []*vault.GateScan {
+	opts.Concurrency = concurrency
+	scans := client.ScanGates(gates, opts)
+
+	for _, scan := range scans {
+		for _, err := range []error{scan.OwnErr, scan.ListErr} {
+			if err != nil && !vault.IsPermissionDenied(err) {
+				fmt.Fprintf(os.Stderr, "Warning: failed to read gate %s: %v\n", scan.Gate.Path, err)
+			}
+		}
+	}
+
+	return scans
 }
 
 // formatOutput handles the common output formatting logic used across commands
@@ -70,6 +107,13 @@ func formatOutput(data interface{}, format string) error {
 		}
 		fmt.Print(string(yamlData))
 
+	case OutputFormatEnv:
+		vars, ok := data.([]envVar)
+		if !ok {
+			return fmt.Errorf("output format %s is not supported by this command", format)
+		}
+		return formatEnvOutput(vars)
+
 	default:
 		return fmt.Errorf("unsupported output format for generic data: %s", format)
 	}
@@ -139,6 +183,14 @@ func formatRequestStatus(status models.AccessRequestStatus) string {
 	}
 }
 
+// formatRequestState returns the colored status of a request, including who revoked it
+func formatRequestState(req *project_models.Request) string {
+	if req.Status == models.Revoked && req.RevokedBy != "" {
+		return fmt.Sprintf("%s (by %s)", formatRequestStatus(req.Status), req.RevokedBy)
+	}
+	return formatRequestStatus(req.Status)
+}
+
 // formatGateDisplay formats gate path with optional default gate highlighting
 func formatGateDisplay(gatePath string) string {
 	cfg := config.GetConfig()
@@ -159,18 +211,43 @@ func wrapError(operation string, err error) error {
 func getVaultClientConfig() *vault.Config {
 	cfg := config.GetConfig()
 	vaultConfig := &vault.Config{
-		Address:   cfg.Vault.Address,
-		Token:     cfg.Vault.Token,
-		Namespace: cfg.Vault.Namespace,
+		Address:     cfg.Vault.Address,
+		Token:       cfg.Vault.Token,
+		Namespace:   cfg.Vault.Namespace,
+		TokenHelper: cfg.Vault.TokenHelper,
+	}
+
+	if tls := cfg.Vault; tls.CACert != "" || tls.CAPath != "" || tls.ClientCert != "" || tls.ClientKey != "" ||
+		tls.TLSServerName != "" || tls.TLSSkipVerify {
+		vaultConfig.TLS = &vault_api.TLSConfig{
+			CACert:        tls.CACert,
+			CAPath:        tls.CAPath,
+			ClientCert:    tls.ClientCert,
+			ClientKey:     tls.ClientKey,
+			TLSServerName: tls.TLSServerName,
+			Insecure:      tls.TLSSkipVerify,
+		}
+	}
+
+	knownGates, err := config.KnownGates()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	}
+	vaultConfig.KnownGates = knownGates
 
 	// Command-line flags override config and env vars
 	if vaultAddr != "" {
 		vaultConfig.Address = vaultAddr
+	} else {
+		// Tokens of the environment are not sent to the Vault server of a project
+		vaultConfig.StoredTokenOnly = config.ProjectVaultAddress()
 	}
 	if vaultToken != "" {
 		vaultConfig.Token = vaultToken
 	}
+	if vaultNamespace != "" {
+		vaultConfig.Namespace = vaultNamespace
+	}
 
 	return vaultConfig
 }
@@ -178,13 +255,13 @@ func getVaultClientConfig() *vault.Config {
 // sendNotificationWithRetry sends a notification with consistent error handling
 // Logs warnings instead of failing if service is unavailable or notification fails
 func sendNotificationWithRetry(svcClient *service.Client, vaultClient *vault.Client, req *project_models.Request, gate string, notificationType service.NotificationType) error {
-	if svcClient == nil {
+	if svcClient == nil || req == nil {
 		return nil
 	}
 
 	accessStruct, err := vaultClient.GetPolicyGateAccessStruct(gate)
 	if err != nil {
-		fmt.Printf("Warning: failed to get gate access struct for notification: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: failed to get gate access struct for notification: %v\n", err)
 		return nil
 	}
 
@@ -193,7 +270,7 @@ func sendNotificationWithRetry(svcClient *service.Client, vaultClient *vault.Cli
 		Gate:    *req.Gate,
 		Access:  *accessStruct,
 	}, notificationType); err != nil {
-		fmt.Printf("Warning: failed to send notification: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notification: %v\n", err)
 	}
 
 	return nil
@@ -204,6 +281,36 @@ func createServiceClient() (*service.Client, error) {
 	return service.NewClient(Version, CommitHash, BuildDate)
 }
 
+// renderGateAccess describes the access granted by the gate according to its gate type.
+// Gates that grant Vault policies also get the detailed access table.
+func renderGateAccess(client *vault.Client, gate string, gateType project_models.GateType, title string) {
+	def, ok := gateType.Definition()
+	if !ok {
+		fmt.Printf("Warning: unsupported gate type: %s\n", gateType)
+		return
+	}
+
+	accessConfig, err := client.GetGateAccessConfig(gate, gateType)
+	if err != nil {
+		fmt.Printf("Warning: failed to get gate access: %v\n", err)
+		return
+	}
+
+	fmt.Println(title)
+	fmt.Printf("  %s\n", def.DescribeAccess(accessConfig))
+
+	if def.GrantedPolicies == nil || len(def.GrantedPolicies(accessConfig)) == 0 {
+		return
+	}
+
+	accessStruct, err := client.GetPolicyGateAccessStruct(gate)
+	if err != nil {
+		fmt.Printf("Warning: failed to get gate access struct: %v\n", err)
+		return
+	}
+	renderAccessTable(*accessStruct)
+}
+
 // renderAccessTable displays Access objects in a table format
 func renderAccessTable(accesses []project_models.Access) {
 	if len(accesses) == 0 {
@@ -213,11 +320,26 @@ func renderAccessTable(accesses []project_models.Access) {
 
 	rows := make([]table.Row, 0)
 	for _, access := range accesses {
-		for accessType, accessBlock := range access.Types {
-			paths := make([]string, len(accessBlock.PathBlock))
-			for i, pb := range accessBlock.PathBlock {
-				caps := strings.Join(pb.Capabilities, ", ")
-				paths[i] = fmt.Sprintf("%s [%s]", pb.Path, caps)
+		if access.Error != "" {
+			rows = append(rows, table.Row{
+				access.Policy,
+				"-",
+				"-",
+				color.RedString("Error: %s", access.Error),
+				"-",
+			})
+			continue
+		}
+		for _, mountPath := range slices.Sorted(maps.Keys(access.Mounts)) {
+			accessBlock := access.Mounts[mountPath]
+			paths := make([]string, 0, len(accessBlock.Paths))
+			for _, mp := range accessBlock.Paths {
+				paths = append(paths, formatMountPath(mp)...)
+			}
+
+			mountType := accessBlock.MountType
+			if accessBlock.Version != "" {
+				mountType = fmt.Sprintf("%s (%s)", mountType, accessBlock.Version)
 			}
 
 			description := accessBlock.Description
@@ -227,14 +349,86 @@ func renderAccessTable(accesses []project_models.Access) {
 
 			rows = append(rows, table.Row{
 				access.Policy,
-				accessType,
+				mountPath,
+				mountType,
 				description,
 				strings.Join(paths, "\n"),
 			})
 		}
+		if len(access.Unmatched) > 0 {
+			paths := make([]string, 0, len(access.Unmatched))
+			for _, pb := range access.Unmatched {
+				paths = append(paths, formatMountPath(project_models.MountPath{PathBlock: pb})...)
+			}
+			rows = append(rows, table.Row{
+				access.Policy,
+				"-",
+				"-",
+				"Not under a known mount",
+				strings.Join(paths, "\n"),
+			})
+		}
 	}
 
 	table.RenderTable(table.TableOptions{
-		Headers: []string{"Policy", "Access Type", "Description", "Paths [Capabilities]"},
+		Headers: []string{"Policy", "Mount", "Type", "Description", "Paths [Capabilities]"},
 	}, rows)
 }
+
+// formatMountPath renders a path of a policy with its capabilities and restrictions.
+// KV paths are shown as the secret path, along with the KV v2 endpoint.
+func formatMountPath(mp project_models.MountPath) []string {
+	caps := strings.Join(mp.Capabilities, ", ")
+
+	var lines []string
+	switch {
+	case mp.LogicalPath != "" && mp.Endpoint != "":
+		lines = append(lines, fmt.Sprintf("%s (%s) [%s]", mp.LogicalPath, mp.Endpoint, caps))
+	case mp.LogicalPath != "":
+		lines = append(lines, fmt.Sprintf("%s [%s]", mp.LogicalPath, caps))
+	default:
+		lines = append(lines, fmt.Sprintf("%s [%s]", mp.Path, caps))
+	}
+
+	for _, restriction := range formatPathRestrictions(mp.PathBlock) {
+		lines = append(lines, "  "+restriction)
+	}
+	return lines
+}
+
+// formatPathRestrictions describes the parameter and response wrapping restrictions of a path block
+func formatPathRestrictions(pb project_models.PathBlock) []string {
+	if !pb.HasRestrictions() {
+		return nil
+	}
+
+	var lines []string
+	for _, name := range slices.Sorted(maps.Keys(pb.AllowedParameters)) {
+		lines = append(lines, fmt.Sprintf("allowed: %s", formatParameterValues(name, pb.AllowedParameters[name])))
+	}
+	for _, name := range slices.Sorted(maps.Keys(pb.DeniedParameters)) {
+		lines = append(lines, fmt.Sprintf("denied: %s", formatParameterValues(name, pb.DeniedParameters[name])))
+	}
+	if len(pb.RequiredParameters) > 0 {
+		lines = append(lines, fmt.Sprintf("required: %s", strings.Join(pb.RequiredParameters, ", ")))
+	}
+	if pb.MinWrappingTTL != "" {
+		lines = append(lines, fmt.Sprintf("min wrapping TTL: %s", pb.MinWrappingTTL))
+	}
+	if pb.MaxWrappingTTL != "" {
+		lines = append(lines, fmt.Sprintf("max wrapping TTL: %s", pb.MaxWrappingTTL))
+	}
+	return lines
+}
+
+// formatParameterValues renders a parameter restriction, where no values means any value
+func formatParameterValues(name string, values []interface{}) string {
+	if len(values) == 0 {
+		return fmt.Sprintf("%s=*", name)
+	}
+	rendered := make([]string, len(values))
+	for i, value := range values {
+		rendered[i] = fmt.Sprint(value)
+	}
+	return fmt.Sprintf("%s=[%s]", name, strings.Join(rendered, ", "))
+}
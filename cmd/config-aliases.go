@@ -0,0 +1,233 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/table"
+	"github.com/gateplane-io/client-cli/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+func configAliasesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "aliases",
+		Short: "Manage gate aliases",
+		Long: `Manage the gate aliases of the profile in use.
+
+Aliases can be shared as a gate catalog file: exported by one user (e.g. a platform team),
+and imported by others, or configured as the gate catalog and imported from it.`,
+	}
+
+	cmd.AddCommand(
+		configAliasesListCmd(),
+		configAliasesRemoveCmd(),
+		configAliasesExportCmd(),
+		configAliasesImportCmd(),
+	)
+
+	return cmd
+}
+
+func configAliasesListCmd() *cobra.Command {
+	var tags []string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List gate aliases",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gates := config.GateAliases(tags...)
+
+			format := getEffectiveOutputFormat()
+			if format == OutputFormatJSON || format == OutputFormatYAML {
+				return formatOutput(gates, format)
+			}
+
+			if len(gates) == 0 {
+				fmt.Println("No gate aliases configured")
+				return nil
+			}
+
+			rows := make([]table.Row, 0, len(gates))
+			for _, gate := range gates {
+				rows = append(rows, table.Row{
+					"@" + gate.Alias,
+					gate.Path,
+					string(gate.Type),
+					gate.Description,
+					strings.Join(gate.Tags, ", "),
+					string(gate.Source),
+				})
+			}
+			fmt.Printf("Profile: %s\n", config.ActiveProfile())
+			table.RenderTable(table.TableOptions{
+				Headers: []string{"Alias", "Path", "Type", "Description", "Tags", "Source"},
+				SortBy:  0,
+				GroupBy: -1,
+			}, rows)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only list the aliases with any of the tags")
+
+	return cmd
+}
+
+func configAliasesRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove [alias...]",
+		Aliases:           []string{"rm"},
+		Short:             "Remove gate aliases",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeGateAliases,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			aliases := make([]string, len(args))
+			for i, alias := range args {
+				aliases[i] = strings.TrimPrefix(alias, "@")
+			}
+
+			if err := config.RemoveGateAliases(aliases); err != nil {
+				return wrapError("remove alias", err)
+			}
+
+			printSuccessMessage("Removed %s", strings.Join(args, ", "))
+			return nil
+		},
+	}
+}
+
+func configAliasesExportCmd() *cobra.Command {
+	var tags []string
+
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export gate aliases as a gate catalog",
+		Long: `Export the gate aliases of the profile in use, with their descriptions and tags,
+as a gate catalog file, written to standard output unless a file is given.`,
+		Example: `  gateplane config aliases export team-gates.yaml
+  gateplane config aliases export --tag production > production-gates.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := config.ExportGateAliases(tags...)
+			if err != nil {
+				return wrapError("export aliases", err)
+			}
+
+			if len(args) == 0 {
+				fmt.Print(string(data))
+				return nil
+			}
+			if err := os.WriteFile(args[0], data, 0644); err != nil {
+				return wrapError("export aliases", err)
+			}
+			printSuccessMessage("Exported gate aliases to %s", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only export the aliases with any of the tags")
+
+	return cmd
+}
+
+func configAliasesImportCmd() *cobra.Command {
+	var options config.ImportOptions
+
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import gate aliases from a gate catalog",
+		Long: `Import the gate aliases of a gate catalog file into the profile in use, along with
+their descriptions and tags. Without a file, the configured gate catalog is imported.
+
+An alias pointing to another gate, or a gate having another alias, is a conflict:
+nothing is imported, unless --overwrite replaces the conflicting aliases or --skip keeps them.`,
+		Example: `  gateplane config aliases import team-gates.yaml
+  gateplane config aliases import --dry-run
+  gateplane config aliases import team-gates.yaml --overwrite`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := config.GetConfig().Catalog
+			if len(args) == 1 {
+				path = args[0]
+			}
+			if path == "" {
+				return fmt.Errorf("no file given and no gate catalog configured, set one with 'gateplane config set catalog <file>'")
+			}
+
+			catalog, err := config.LoadGateCatalog(path)
+			if err != nil {
+				return wrapError("import aliases", err)
+			}
+
+			changes, importErr := config.ImportGateAliases(catalog, options)
+
+			format := getEffectiveOutputFormat()
+			switch {
+			case format == OutputFormatJSON || format == OutputFormatYAML:
+				if err := formatOutput(changes, format); err != nil {
+					return err
+				}
+			case len(changes) == 0:
+				fmt.Printf("No gate aliases found in %s\n", path)
+			default:
+				rows := make([]table.Row, 0, len(changes))
+				for _, change := range changes {
+					rows = append(rows, table.Row{"@" + change.Alias, change.Path, change.Action, change.Detail})
+				}
+				table.RenderTable(table.TableOptions{
+					Headers: []string{"Alias", "Path", "Action", "Detail"},
+					SortBy:  -1,
+					GroupBy: -1,
+				}, rows)
+			}
+
+			if importErr != nil {
+				return wrapError("import aliases", importErr)
+			}
+			switch {
+			case format == OutputFormatJSON || format == OutputFormatYAML:
+			case options.DryRun:
+				fmt.Println("Dry run, nothing was imported")
+			default:
+				printSuccessMessage("Imported gate aliases from %s", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.Overwrite, "overwrite", false, "Replace the conflicting aliases with the imported ones")
+	cmd.Flags().BoolVar(&options.Skip, "skip", false, "Keep the conflicting aliases, importing the others")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "Show what would be imported, without saving")
+	cmd.MarkFlagsMutuallyExclusive("overwrite", "skip")
+
+	return cmd
+}
+
+// completeGateAliases completes the gate aliases of the profile in use
+func completeGateAliases(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Completions run without the root command initializing the configuration
+	config.GetConfig()
+
+	var aliases []string
+	for _, gate := range config.GateAliases() {
+		if gate.Source == models.SourceConfig {
+			aliases = append(aliases, gate.Alias+"\t"+gate.Path)
+		}
+	}
+	return aliases, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/table"
+	"github.com/spf13/cobra"
+)
+
+func configGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [key]",
+		Short: "Get a configuration value, or all of them",
+		Long: `Get a configuration value of the profile in use, or all of them.
+` + settingKeysHelp(),
+		Example: `  gateplane config get vault.address
+  gateplane config get profiles.staging.vault.namespace
+  gateplane config get gates.prod-ssh
+  gateplane config get -o yaml`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeSettingArgs(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				value, err := config.GetSetting(args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Println(value)
+				return nil
+			}
+
+			values := config.AllSettings()
+			format := getEffectiveOutputFormat()
+			if format == OutputFormatJSON || format == OutputFormatYAML {
+				return formatOutput(values, format)
+			}
+
+			rows := make([]table.Row, 0, len(values))
+			for key, value := range values {
+				rows = append(rows, table.Row{key, value})
+			}
+			fmt.Printf("Profile: %s\n", config.ActiveProfile())
+			table.RenderTable(table.TableOptions{
+				Headers: []string{"Key", "Value"},
+				SortBy:  0,
+				GroupBy: -1,
+			}, rows)
+			return nil
+		},
+	}
+}
+
+func configSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: "Set a configuration value",
+		Long: `Set a configuration value of the profile in use.
+` + settingKeysHelp(),
+		Example: `  gateplane config set vault.address https://vault.example.com:8200
+  gateplane config set vault.ca_cert ./ca.pem
+  gateplane config set defaults.output_format yaml
+  gateplane config set profiles.staging.vault.namespace team-a
+  gateplane config set gates.prod-ssh gates/production/ssh`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeSettingArgs(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.SetSetting(args[0], args[1]); err != nil {
+				return err
+			}
+			value, err := config.GetSetting(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s set to: %s\n", args[0], value)
+			warnSettingOverride(args[0])
+			return nil
+		},
+	}
+
+	// Kept for compatibility
+	cmd.AddCommand(
+		configSetVaultAddressCmd(),
+		configSetDefaultGateCmd(),
+		configSetOutputFormatCmd(),
+	)
+
+	return cmd
+}
+
+func configUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset [key]",
+		Short: "Reset a configuration value, or remove a gate alias",
+		Long: `Reset a configuration value of the profile in use to its default, or remove a gate alias.
+` + settingKeysHelp(),
+		Example: `  gateplane config unset vault.namespace
+  gateplane config unset gates.prod-ssh`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSettingArgs(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.UnsetSetting(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("%s unset\n", args[0])
+			warnSettingOverride(args[0])
+			return nil
+		},
+	}
+}
+
+// warnSettingOverride warns when the saved value of a key is not the one in effect here,
+// as the environment or the project configuration overrides it
+func warnSettingOverride(key string) {
+	origin, ok := config.SettingOverride(key)
+	if !ok {
+		return
+	}
+	if env, isEnv := strings.CutPrefix(origin, "env "); isEnv {
+		origin = "the environment variable " + env
+	} else {
+		origin = "the project configuration " + origin
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s is overridden by %s\n", key, origin)
+}
+
+// settingKeysHelp lists the configuration keys for the help of 'config get/set/unset'
+func settingKeysHelp() string {
+	var b strings.Builder
+
+	b.WriteString("\nKeys:\n")
+	for _, setting := range config.Settings() {
+		description := setting.Description
+		switch {
+		case len(setting.Values) > 0:
+			description += " (" + strings.Join(setting.Values, ", ") + ")"
+		case setting.Type == "bool":
+			description += " (true, false)"
+		}
+		if setting.Global {
+			description += ", for all profiles"
+		}
+		fmt.Fprintf(&b, "  %-24s %s\n", setting.Key, description)
+	}
+	fmt.Fprintf(&b, "  %-24s %s\n", config.AliasKeyPrefix+"<alias>", "Path of a gate alias")
+	fmt.Fprintf(&b, "\nThe keys of another profile are prefixed with '%s<profile>.'", config.ProfileKeyPrefix)
+
+	return b.String()
+}
+
+// completeSettingArgs completes the configuration keys and, for 'config set', their values
+func completeSettingArgs(withValue bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch {
+		case len(args) == 0:
+			return completeSettingKeys(toComplete)
+		case len(args) == 1 && withValue:
+			return completeSettingValue(args[0])
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeSettingKeys(toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Completions run without the root command initializing the configuration
+	config.GetConfig()
+
+	prefix := ""
+	if rest, ok := strings.CutPrefix(toComplete, config.ProfileKeyPrefix); ok {
+		name, _, found := strings.Cut(rest, ".")
+		if !found {
+			var profiles []string
+			for _, profile := range config.ProfileNames() {
+				profiles = append(profiles, config.ProfileKeyPrefix+profile+".")
+			}
+			return profiles, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+		}
+		prefix = config.ProfileKeyPrefix + name + "."
+	}
+
+	var keys []string
+	for _, setting := range config.Settings() {
+		if setting.Global && prefix != "" {
+			continue
+		}
+		keys = append(keys, prefix+setting.Key+"\t"+setting.Description)
+	}
+	for _, gate := range config.Gates() {
+		if gate.Alias != "" && prefix == "" {
+			keys = append(keys, config.AliasKeyPrefix+gate.Alias+"\t"+gate.Path)
+		}
+	}
+	if prefix == "" {
+		keys = append(keys, config.ProfileKeyPrefix+"\tSettings of another profile")
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeSettingValue(key string) ([]string, cobra.ShellCompDirective) {
+	// Keys of another profile are completed like those of the profile in use
+	if rest, ok := strings.CutPrefix(key, config.ProfileKeyPrefix); ok {
+		_, key, _ = strings.Cut(rest, ".")
+	}
+
+	for _, setting := range config.Settings() {
+		if setting.Key != key {
+			continue
+		}
+		switch {
+		case len(setting.Values) > 0:
+			return setting.Values, cobra.ShellCompDirectiveNoFileComp
+		case setting.Type == "bool":
+			return []string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+		case key == "vault.ca_path":
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		case key == "vault.ca_cert", key == "vault.client_cert", key == "vault.client_key",
+			key == "vault.token_helper", key == "catalog":
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/table"
+	"github.com/gateplane-io/client-cli/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func configValidateCmd() *cobra.Command {
+	var offline bool
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration file for errors",
+		Long: `Check the configuration file for unknown keys, invalid values, duplicate gate aliases
+and default gates that do not exist. Unless --offline, the default gate of the profile
+in use is also looked up in Vault.
+
+Exits with an error if any errors are found, warnings alone do not fail.`,
+		Example: `  gateplane config validate
+  gateplane config validate --offline -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var checkGate config.GateChecker
+			if !offline {
+				checkGate = checkDefaultGate
+			}
+
+			issues, err := config.ValidateConfig(checkGate)
+			if err != nil {
+				return wrapError("validate config", err)
+			}
+
+			format := getEffectiveOutputFormat()
+			switch {
+			case format == OutputFormatJSON || format == OutputFormatYAML:
+				if issues == nil {
+					issues = []config.ValidationIssue{}
+				}
+				if err := formatOutput(issues, format); err != nil {
+					return err
+				}
+			case len(issues) > 0:
+				rows := make([]table.Row, 0, len(issues))
+				for _, issue := range issues {
+					rows = append(rows, table.Row{strconv.Itoa(issue.Line), issue.Severity, issue.Key, issue.Message})
+				}
+				fmt.Printf("Config file: %s\n", config.GetConfigFile())
+				table.RenderTable(table.TableOptions{
+					Headers: []string{"Line", "Severity", "Key", "Message"},
+					SortBy:  -1,
+					GroupBy: -1,
+				}, rows)
+			}
+
+			failed := 0
+			for _, issue := range issues {
+				if issue.Severity == config.SeverityError {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d error(s) found in %s", failed, config.GetConfigFile())
+			}
+			if format != OutputFormatJSON && format != OutputFormatYAML {
+				printSuccessMessage("%s is valid", config.GetConfigFile())
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&offline, "offline", false, "Do not look up the default gate in Vault")
+
+	return cmd
+}
+
+// checkDefaultGate looks up the default gate of the profile in use in Vault.
+// The gates of other profiles are not checked, as they use other Vault servers.
+func checkDefaultGate(profile, gate string) error {
+	if profile != config.ActiveProfile() {
+		return nil
+	}
+
+	client, err := createVaultClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.VaultClient().Logical().Read(gate + "/config")
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.ErrGateNotFound
+	}
+	return nil
+}
@@ -12,8 +12,12 @@ package main
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/table"
 	"github.com/gateplane-io/client-cli/pkg/models"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -29,20 +33,44 @@ func configCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		configShowCmd(),
+		configGetCmd(),
 		configSetCmd(),
+		configUnsetCmd(),
 		configAddAliasCmd(),
+		configAliasesCmd(),
 		configUseProfileCmd(),
+		configProfileCmd(),
+		configCredentialsCmd(),
+		configValidateCmd(),
 	)
 
 	return cmd
 }
 
+// settingOrigin describes a setting in 'config show --origin'
+type settingOrigin struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Origin string `json:"origin" yaml:"origin"`
+}
+
 func configShowCmd() *cobra.Command {
-	return &cobra.Command{
+	var origin bool
+
+	cmd := &cobra.Command{
 		Use:     "show",
-		Aliases: []string{"get", "view"},
+		Aliases: []string{"view"},
 		Short:   "Show current configuration",
+		Long: `Show the configuration in effect for the profile in use.
+
+Environment variables (VAULT_ADDR, VAULT_NAMESPACE, VAULT_TOKEN and GATEPLANE_<KEY>,
+e.g. GATEPLANE_DEFAULTS_GATE), ~/.vault-token and flags apply to the current command only,
+and are never saved. With --origin, the source of each value is shown.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if origin {
+				return showSettingOrigins()
+			}
+
 			cfg := config.GetConfig()
 
 			// Mask token for security
@@ -55,38 +83,85 @@ func configShowCmd() *cobra.Command {
 				displayCfg.Service.JWT = "DATA+OMITTED"
 			}
 
+			if format := getEffectiveOutputFormat(); format == OutputFormatEnv {
+				// Export the active connection, not the stored secrets
+				vaultConfig := getVaultClientConfig()
+				return formatOutput(connectionEnvVars(vaultConfig.Address, vaultConfig.Namespace, ""), format)
+			}
+
 			yamlData, err := yaml.Marshal(displayCfg)
 			if err != nil {
 				return wrapError("marshal config", err)
 			}
 
+			fmt.Printf("# Profile: %s\n", config.ActiveProfile())
 			fmt.Print(string(yamlData))
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&origin, "origin", false, "Show where each value comes from")
+
+	return cmd
 }
 
-func configSetCmd() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "set",
-		Short: "Set configuration values",
-		Long:  "Set configuration values",
+// showSettingOrigins prints the settings in effect, along with their origin
+func showSettingOrigins() error {
+	cfg := config.GetConfig()
+	origins := config.SettingOrigins()
+
+	values := config.AllSettings()
+	if origins["vault.token"] != "" {
+		values["vault.token"] = maskSecret(cfg.Vault.Token)
+	}
+	if origins["service.jwt"] != "" {
+		values["service.jwt"] = maskSecret(cfg.Service.JWT)
 	}
 
-	cmd.AddCommand(
-		configSetVaultAddressCmd(),
-		configSetDefaultGateCmd(),
-		configSetOutputFormatCmd(),
-	)
+	// Command-line flags override the configuration and the environment
+	if vaultAddr != "" {
+		values["vault.address"], origins["vault.address"] = vaultAddr, "flag --vault-addr"
+	}
+	if vaultToken != "" {
+		values["vault.token"], origins["vault.token"] = maskSecret(vaultToken), "flag --vault-token"
+	}
 
-	return cmd
+	settings := make([]settingOrigin, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		settings = append(settings, settingOrigin{Key: key, Value: values[key], Origin: origins[key]})
+	}
+
+	format := getEffectiveOutputFormat()
+	if format == OutputFormatJSON || format == OutputFormatYAML {
+		return formatOutput(settings, format)
+	}
+
+	rows := make([]table.Row, 0, len(settings))
+	for _, setting := range settings {
+		rows = append(rows, table.Row{setting.Key, setting.Value, setting.Origin})
+	}
+	fmt.Printf("Profile: %s\n", config.ActiveProfile())
+	table.RenderTable(table.TableOptions{
+		Headers: []string{"Key", "Value", "Origin"},
+		SortBy:  0,
+		GroupBy: -1,
+	}, rows)
+	return nil
+}
+
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "DATA+OMITTED"
 }
 
 func configSetVaultAddressCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "vault-address [address]",
-		Short: "Set Vault address",
-		Args:  cobra.ExactArgs(1),
+		Use:        "vault-address [address]",
+		Deprecated: "use 'gateplane config set vault.address <value>'",
+		Short:      "Set Vault address",
+		Args:       cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.SetVaultAddress(args[0]); err != nil {
 				return wrapError("set vault address", err)
@@ -99,9 +174,10 @@ func configSetVaultAddressCmd() *cobra.Command {
 
 func configSetDefaultGateCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "default-gate [gate]",
-		Short: "Set default gate",
-		Args:  cobra.ExactArgs(1),
+		Use:        "default-gate [gate]",
+		Deprecated: "use 'gateplane config set defaults.gate <value>'",
+		Short:      "Set default gate",
+		Args:       cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.SetDefaultGate(args[0]); err != nil {
 				return wrapError("set default gate", err)
@@ -114,18 +190,17 @@ func configSetDefaultGateCmd() *cobra.Command {
 
 func configSetOutputFormatCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "output-format [format]",
-		Short: "Set default output format (table, json, yaml)",
-		Args:  cobra.ExactArgs(1),
+		Use:        "output-format [format]",
+		Deprecated: "use 'gateplane config set defaults.output_format <value>'",
+		Short:      "Set default output format (table, json, yaml)",
+		Args:       cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			format := args[0]
 			if format != "table" && format != "json" && format != "yaml" {
 				return fmt.Errorf("invalid output format: %s. Must be one of: table, json, yaml", format)
 			}
 
-			cfg := config.GetConfig()
-			cfg.Defaults.OutputFormat = format
-			if err := config.SaveConfig(); err != nil {
+			if err := config.SetSetting("defaults.output_format", format); err != nil {
 				return wrapError("save config", err)
 			}
 			fmt.Printf("Default output format set to: %s\n", format)
@@ -135,7 +210,8 @@ func configSetOutputFormatCmd() *cobra.Command {
 }
 
 func configAddAliasCmd() *cobra.Command {
-	var gateType string
+	var gateType, description string
+	var tags []string
 
 	cmd := &cobra.Command{
 		Use:     "add-alias [gate-path] [alias]",
@@ -146,17 +222,13 @@ func configAddAliasCmd() *cobra.Command {
 			gatePath := args[0]
 			alias := args[1]
 
-			var gt models.GateType
-			switch gateType {
-			case "policy", "policy-gate":
-				gt = models.PolicyGate
-			case "okta", "okta-group-gate":
-				gt = models.OktaGroupGate
-			default:
-				gt = models.PolicyGate // Default
+			def, ok := models.LookupGateType(gateType)
+			if !ok {
+				return fmt.Errorf("unknown gate type: %s. Must be one of: %s", gateType, strings.Join(models.GateTypeNames(), ", "))
 			}
 
-			if err := config.AddGateAlias(gatePath, alias, gt); err != nil {
+			gate := models.Gate{Path: gatePath, Alias: alias, Type: def.Type, Description: description, Tags: tags}
+			if err := config.AddGateAlias(gate); err != nil {
 				return wrapError("add alias", err)
 			}
 
@@ -165,23 +237,20 @@ func configAddAliasCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&gateType, "type", "policy-gate", "Gate type (policy-gate, okta-group-gate)")
+	cmd.Flags().StringVar(&gateType, "type", string(models.PolicyGate), fmt.Sprintf("Gate type (%s)", strings.Join(models.GateTypeNames(), ", ")))
+	cmd.Flags().StringVar(&description, "description", "", "Description of the gate")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Tags of the gate, e.g. to export them selectively")
 
 	return cmd
 }
 
 func configUseProfileCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:     "use-profile [profile]",
-		Aliases: []string{"profile"},
-		Short:   "Switch to a different configuration profile",
-		Args:    cobra.ExactArgs(1),
+		Use:   "use-profile [profile]",
+		Short: "Switch to a different configuration profile",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := config.UseProfile(args[0]); err != nil {
-				return wrapError("use profile", err)
-			}
-			fmt.Printf("Switched to profile: %s\n", args[0])
-			return nil
+			return useProfile(args[0])
 		},
 	}
 }
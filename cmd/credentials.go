@@ -0,0 +1,161 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func configCredentialsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "credentials",
+		Aliases: []string{"creds"},
+		Short:   "Manage the credential store",
+		Long: `Manage the credential store, where Vault tokens and the GatePlane Services token are kept
+(readable only by the user). The store can be encrypted with a passphrase, which is prompted for
+or read from ` + config.PassphraseEnv + `.`,
+	}
+
+	cmd.AddCommand(
+		configCredentialsStatusCmd(),
+		configCredentialsEncryptCmd(),
+		configCredentialsDecryptCmd(),
+	)
+
+	return cmd
+}
+
+func configCredentialsStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show where and how credentials are stored",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stored := config.StoredCredentials()
+
+			fmt.Printf("Credential store: %s\n", config.CredentialsFilePath())
+			fmt.Printf("Backend: %s\n", config.CredentialsBackend())
+			if config.CredentialsLocked() {
+				printFailedMessage("Locked (set %s to unlock)", config.PassphraseEnv)
+				return nil
+			}
+			if len(stored.VaultTokens) == 0 {
+				fmt.Println("Vault tokens: none")
+			} else {
+				fmt.Println("Vault tokens (profile@address):")
+				for _, key := range slices.Sorted(maps.Keys(stored.VaultTokens)) {
+					fmt.Printf("  %s\n", key)
+				}
+			}
+			fmt.Printf("GatePlane Services token: %s\n", formatStoredSecret(stored.ServiceJWT))
+			return nil
+		},
+	}
+}
+
+func configCredentialsEncryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the credential store with a passphrase",
+		Long: `Encrypt the credential store with a passphrase (AES-256-GCM, with a PBKDF2-derived key).
+Also changes the passphrase of an already encrypted store.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase := os.Getenv(config.PassphraseEnv)
+			if passphrase == "" {
+				var err error
+				passphrase, err = promptNewPassphrase()
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := config.SetCredentialsBackend(config.CredentialsEncrypted, passphrase); err != nil {
+				return wrapError("encrypt credentials", err)
+			}
+			printSuccessMessage("Credential store encrypted: %s", config.CredentialsFilePath())
+			return nil
+		},
+	}
+}
+
+func configCredentialsDecryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "decrypt",
+		Short: "Store credentials without encryption",
+		Long:  "Store credentials without encryption, protected only by file permissions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.SetCredentialsBackend(config.CredentialsPlaintext, ""); err != nil {
+				return wrapError("decrypt credentials", err)
+			}
+			printSuccessMessage("Credential store decrypted: %s", config.CredentialsFilePath())
+			return nil
+		},
+	}
+}
+
+// promptCredentialsPassphrase asks for the passphrase of the encrypted credential store
+func promptCredentialsPassphrase() (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("the credential store is encrypted, set %s to unlock it", config.PassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "Credential store passphrase: ")
+	input, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", wrapError("read passphrase", err)
+	}
+	return string(input), nil
+}
+
+// promptNewPassphrase asks for a new passphrase twice
+func promptNewPassphrase() (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("no passphrase provided. Set %s or run in a terminal", config.PassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "New passphrase: ")
+	first, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", wrapError("read passphrase", err)
+	}
+	fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+	second, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", wrapError("read passphrase", err)
+	}
+
+	if string(first) != string(second) {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	if len(first) == 0 {
+		return "", fmt.Errorf("the passphrase cannot be empty")
+	}
+	return string(first), nil
+}
+
+func formatStoredSecret(secret string) string {
+	if secret == "" {
+		return "not set"
+	}
+	return "set"
+}
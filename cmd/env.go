@@ -0,0 +1,113 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Shells supported by the 'env' output format
+const (
+	ShellBash       = "bash"
+	ShellZsh        = "zsh"
+	ShellFish       = "fish"
+	ShellPowerShell = "powershell"
+)
+
+// envVar is a single environment variable rendered by the 'env' output format
+type envVar struct {
+	Name  string
+	Value string
+}
+
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// getEffectiveShell returns the shell to render 'env' output for, checking flag -> $SHELL -> bash
+func getEffectiveShell() (string, error) {
+	shell := envShell
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+	}
+
+	switch strings.ToLower(shell) {
+	case ShellBash, "sh":
+		return ShellBash, nil
+	case ShellZsh:
+		return ShellZsh, nil
+	case ShellFish:
+		return ShellFish, nil
+	case ShellPowerShell, "pwsh":
+		return ShellPowerShell, nil
+	default:
+		if envShell == "" {
+			// Unknown login shell, fall back to POSIX syntax
+			return ShellBash, nil
+		}
+		return "", fmt.Errorf("unsupported shell: %s. Must be one of: bash, zsh, fish, powershell", shell)
+	}
+}
+
+// formatEnvOutput prints variables as statements that can be evaluated by the shell
+func formatEnvOutput(vars []envVar) error {
+	shell, err := getEffectiveShell()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range vars {
+		if !envNameRegexp.MatchString(v.Name) {
+			return fmt.Errorf("invalid environment variable name: %q", v.Name)
+		}
+
+		switch shell {
+		case ShellFish:
+			fmt.Printf("set -gx %s %s;\n", v.Name, quoteFish(v.Value))
+		case ShellPowerShell:
+			fmt.Printf("$Env:%s = %s\n", v.Name, quotePowerShell(v.Value))
+		default: // bash, zsh
+			fmt.Printf("export %s=%s\n", v.Name, quotePOSIX(v.Value))
+		}
+	}
+	return nil
+}
+
+// quotePOSIX single-quotes a value for bash/zsh, where nothing is special inside single quotes
+func quotePOSIX(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// quoteFish single-quotes a value for fish, where only backslash and single quote are escaped
+func quoteFish(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "'", `\'`)
+	return "'" + value + "'"
+}
+
+// quotePowerShell single-quotes a value for PowerShell, where single quotes are doubled
+func quotePowerShell(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// connectionEnvVars returns the variables pointing Vault clients to the given connection
+func connectionEnvVars(address, namespace, token string) []envVar {
+	vars := []envVar{{Name: "VAULT_ADDR", Value: address}}
+	if namespace != "" {
+		vars = append(vars, envVar{Name: "VAULT_NAMESPACE", Value: namespace})
+	}
+	if token != "" {
+		vars = append(vars, envVar{Name: "VAULT_TOKEN", Value: token})
+	}
+	return vars
+}
@@ -0,0 +1,282 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/gateplane-io/client-cli/internal/service"
+	"github.com/gateplane-io/client-cli/internal/vault"
+	"github.com/spf13/cobra"
+
+	base "github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+// exitCodeError carries the exit code of a child process back to main()
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("command exited with code %d", e.code)
+}
+
+func shellCmd() *cobra.Command {
+	var (
+		interactive bool
+		release     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "shell [gate]",
+		Short: "Start a subshell with the claimed access of a gate",
+		Long: `Start an interactive subshell with VAULT_TOKEN, VAULT_ADDR and VAULT_NAMESPACE
+set to the claimed access of a gate. Approved requests are claimed first.
+The credentials never touch ~/.vault-token or the shell history.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useInteractive := isInteractiveMode(interactive, len(args) > 0, false)
+
+			client, err := createVaultClient()
+			if err != nil {
+				return wrapError("create vault client", err)
+			}
+
+			svcClient, err := createServiceClient()
+			if err != nil {
+				fmt.Println("Not authenticated with GatePlane Services (using Community Edition features)")
+				svcClient = nil
+			}
+
+			var gate string
+			if useInteractive {
+				gate, err = selectUsableGateInteractively(client)
+				if err != nil || gate == "" {
+					return err
+				}
+			} else {
+				gate, err = resolveGateFromArgs(args)
+				if err != nil {
+					return err
+				}
+			}
+
+			req, err := client.GetRequestStatus(gate)
+			if err != nil {
+				return wrapError("get request status", err)
+			}
+
+			var claimResponse map[string]interface{}
+			switch {
+			case req != nil && req.Status == base.Approved:
+				claimResponse, err = claimAccess(client, svcClient, gate)
+				if err != nil {
+					return err
+				}
+				printSuccessMessage("Access claimed successfully on gate: %s", gate)
+			case req != nil && req.Status == base.Active:
+				// Already claimed, the access is attached to the current token
+			default:
+				status := "none"
+				if req != nil {
+					status = req.Status.String()
+				}
+				return wrapError("start shell", fmt.Errorf("no approved or active access on gate %s (status: %s)", gate, status))
+			}
+
+			shellArgs, shellEnv, cleanup, err := subshellCommand(gate)
+			if err != nil {
+				return wrapError("prepare shell", err)
+			}
+			defer cleanup()
+
+			fmt.Fprintf(os.Stderr, "Starting a shell with access on gate %s. Exit the shell to return.\n", gate)
+			return runWithClaimedAccess(cmd, client, svcClient, gate, claimResponse, shellArgs, release, shellEnv...)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().BoolVar(&release, "release", false, "Release the claimed access when the shell exits")
+
+	return cmd
+}
+
+// splitExecArgs splits the positional arguments at '--' into gate arguments and a command
+func splitExecArgs(cmd *cobra.Command, args []string) ([]string, []string) {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		return args, nil
+	}
+	return args[:dash], args[dash:]
+}
+
+// runWithClaimedAccess runs a child process with the claimed credentials injected in its environment.
+// A non-zero exit code of the child is returned as an *exitCodeError.
+func runWithClaimedAccess(cmd *cobra.Command, client *vault.Client, svcClient *service.Client, gate string, claimResponse map[string]interface{}, command []string, release bool, extraEnv ...envVar) error {
+	vars := append(claimEnvVars(client, claimResponse), envVar{Name: "GATEPLANE_GATE", Value: gate})
+	vars = append(vars, extraEnv...)
+
+	code, err := runChild(command, mergeEnv(os.Environ(), vars))
+
+	if release {
+		if err := releaseAccess(client, svcClient, gate); err != nil {
+			printFailedMessage("%v", err)
+		}
+	}
+
+	if err != nil {
+		return wrapError("run command", err)
+	}
+	if code != 0 {
+		// The child already reported its own errors
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &exitCodeError{code: code}
+	}
+	return nil
+}
+
+// runChild runs the command attached to the current terminal, forwarding termination signals.
+// Returns the exit code of the command.
+func runChild(command []string, env []string) (int, error) {
+	child := exec.Command(command[0], command[1:]...)
+	child.Env = env
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	// The child handles the signals, the CLI waits for it to exit.
+	// Ctrl-C already reaches the child through the terminal, so it is only caught here (not ignored,
+	// which the child would inherit), while the signals not coming from the terminal are forwarded.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGHUP)
+
+	if err := child.Start(); err != nil {
+		signal.Stop(signals)
+		return 0, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for sig := range signals {
+			_ = child.Process.Signal(sig)
+		}
+	}()
+
+	err := child.Wait()
+	signal.Stop(signals)
+	close(signals)
+	<-done
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// mergeEnv overrides (or adds) variables in an environment list
+func mergeEnv(environ []string, vars []envVar) []string {
+	overridden := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		overridden[v.Name] = true
+	}
+
+	merged := make([]string, 0, len(environ)+len(vars))
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		if !overridden[name] {
+			merged = append(merged, entry)
+		}
+	}
+	for _, v := range vars {
+		merged = append(merged, v.Name+"="+v.Value)
+	}
+	return merged
+}
+
+// subshellCommand returns the command line and environment of an interactive shell
+// whose prompt is marked with the gate. The cleanup function removes any temporary files.
+func subshellCommand(gate string) ([]string, []envVar, func(), error) {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	marker := fmt.Sprintf("(gateplane:%s) ", gate)
+	noop := func() {}
+
+	switch filepath.Base(shell) {
+	case ShellBash:
+		rcFile, err := os.CreateTemp("", "gateplane-bashrc-*")
+		if err != nil {
+			return nil, nil, noop, err
+		}
+		rc := fmt.Sprintf("[ -f ~/.bashrc ] && . ~/.bashrc\nPS1=%s\"$PS1\"\n", quotePOSIX(marker))
+		if _, err := rcFile.WriteString(rc); err != nil {
+			_ = rcFile.Close()
+			_ = os.Remove(rcFile.Name())
+			return nil, nil, noop, err
+		}
+		_ = rcFile.Close()
+		return []string{shell, "--rcfile", rcFile.Name(), "-i"}, nil, func() { _ = os.Remove(rcFile.Name()) }, nil
+
+	case ShellZsh:
+		zdotdir, err := os.MkdirTemp("", "gateplane-zsh-*")
+		if err != nil {
+			return nil, nil, noop, err
+		}
+		originalZdotdir := os.Getenv("ZDOTDIR")
+		if originalZdotdir == "" {
+			originalZdotdir = os.Getenv("HOME")
+		}
+		rc := fmt.Sprintf("ZDOTDIR=%s\n[ -f \"$ZDOTDIR/.zshrc\" ] && . \"$ZDOTDIR/.zshrc\"\nPROMPT=%s\"$PROMPT\"\n",
+			quotePOSIX(originalZdotdir), quotePOSIX(marker))
+		if err := os.WriteFile(filepath.Join(zdotdir, ".zshrc"), []byte(rc), 0600); err != nil {
+			_ = os.RemoveAll(zdotdir)
+			return nil, nil, noop, err
+		}
+		return []string{shell, "-i"}, []envVar{{Name: "ZDOTDIR", Value: zdotdir}}, func() { _ = os.RemoveAll(zdotdir) }, nil
+
+	case ShellFish:
+		init := fmt.Sprintf("functions -c fish_prompt __gateplane_fish_prompt; function fish_prompt; echo -n %s; __gateplane_fish_prompt; end",
+			quoteFish(marker))
+		return []string{shell, "-i", "-C", init}, nil, noop, nil
+
+	default:
+		return []string{shell, "-i"}, []envVar{{Name: "PS1", Value: marker + "$ "}}, noop, nil
+	}
+}
+
+// selectUsableGateInteractively lets the user pick one of the gates with an Approved or Active request.
+// Returns an empty gate if there is nothing to use.
+func selectUsableGateInteractively(client *vault.Client) (string, error) {
+	gates, err := client.DiscoverGates()
+	if err != nil {
+		return "", wrapError("discover gates", err)
+	}
+
+	return selectOwnRequestGateInteractively(client, gates, "No Approved or Active access available.",
+		base.Approved, base.Active)
+}
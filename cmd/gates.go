@@ -15,6 +15,7 @@ import (
 
 	"github.com/gateplane-io/client-cli/internal/config"
 	"github.com/gateplane-io/client-cli/internal/table"
+	"github.com/gateplane-io/client-cli/pkg/models"
 
 	"github.com/spf13/cobra"
 )
@@ -41,8 +42,6 @@ func gatesListCmd() *cobra.Command {
 		Aliases: []string{"ls", "l"},
 		Short:   "List all discovered gates",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg := config.GetConfig()
-
 			client, err := createVaultClient()
 			if err != nil {
 				return wrapError("create vault client", err)
@@ -55,8 +54,8 @@ func gatesListCmd() *cobra.Command {
 
 			// Add aliases from config
 			for _, gate := range gates {
-				for _, cfgGate := range cfg.Gates {
-					if gate.Path == cfgGate.Path {
+				for _, cfgGate := range config.Gates() {
+					if gate.Path == cfgGate.Path && cfgGate.Alias != "" {
 						gate.Alias = cfgGate.Alias
 						break
 					}
@@ -79,11 +78,12 @@ func gatesListCmd() *cobra.Command {
 						string(gate.Type),
 						gate.Alias,
 						gate.Description,
+						string(gate.Source),
 					})
 				}
 
 				table.RenderTable(table.TableOptions{
-					Headers: []string{"Path", "Type", "Alias", "Description"},
+					Headers: []string{"Path", "Type", "Alias", "Description", "Source"},
 					SortBy:  0,  // Sort by Path
 					GroupBy: -1, // No grouping for gates list
 				}, rows)
@@ -120,10 +120,10 @@ func gatesInfoCmd() *cobra.Command {
 				return nil
 			}
 
-			accessStruct, err := client.GetPolicyGateAccessStruct(gatePath)
+			gateType, err := client.GetGateType(gatePath)
 			if err != nil {
-				fmt.Printf("Warning: failed to get gate access struct for notification: %v\n", err)
-				return nil
+				fmt.Printf("Warning: failed to determine gate type, assuming %s: %v\n", models.PolicyGate, err)
+				gateType = models.PolicyGate
 			}
 
 			format := getEffectiveOutputFormat()
@@ -131,8 +131,23 @@ func gatesInfoCmd() *cobra.Command {
 				// Combine config and access into a single object for structured output
 				gateInfo := map[string]interface{}{
 					"path":   gatePath,
+					"type":   gateType,
 					"config": resp.Data,
-					"access": *accessStruct,
+				}
+
+				def, _ := gateType.Definition()
+				accessConfig, err := client.GetGateAccessConfig(gatePath, gateType)
+				if err != nil {
+					return wrapError("get gate access", err)
+				}
+				gateInfo["access_config"] = accessConfig
+
+				if def.GrantedPolicies != nil && len(def.GrantedPolicies(accessConfig)) > 0 {
+					accessStruct, err := client.GetPolicyGateAccessStruct(gatePath)
+					if err != nil {
+						return wrapError("get gate access struct", err)
+					}
+					gateInfo["access"] = *accessStruct
 				}
 				return formatOutput(gateInfo, format)
 			}
@@ -140,8 +155,8 @@ func gatesInfoCmd() *cobra.Command {
 			// Table format
 			renderGateConfigTable(gatePath, resp.Data)
 
-			fmt.Println("\nAccess:")
-			renderAccessTable(*accessStruct)
+			fmt.Println()
+			renderGateAccess(client, gatePath, gateType, "Access:")
 
 			return nil
 		},
@@ -11,10 +11,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/vault"
 	"github.com/spf13/cobra"
 )
 
@@ -28,24 +30,48 @@ var (
 	vaultToken   string
 	vaultAddr    string
 	outputFormat string
+	envShell     string
+	concurrency  int
+	profileName  string
+	configFile   string
+	// Vault namespace of the current command, set by 'auth login --namespace'
+	vaultNamespace string
 
 	rootCmd = &cobra.Command{
 		Use:   "gateplane",
 		Short: "CLI for GatePlane - Just-In-Time Access Management",
 		Long: `GatePlane CLI provides command-line access to GatePlane gates for
 requesting, approving, and claiming time-limited access to protected resources.`,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			config.SetConfigFile(configFile)
 			if err := config.Init(); err != nil {
 				fmt.Fprintf(os.Stderr, "Error initializing config: %v\n", err)
 			}
+
+			// A profile selected for this command only
+			if profileName == "" {
+				profileName = os.Getenv(config.ProfileEnv)
+			}
+			if profileName != "" {
+				if err := config.ApplyProfile(profileName); err != nil {
+					return wrapError("use profile", err)
+				}
+			}
+			return nil
 		},
 	}
 )
 
 func init() {
+	config.SetPassphraseFunc(promptCredentialsPassphrase)
+
 	rootCmd.PersistentFlags().StringVarP(&vaultToken, "vault-token", "t", "", "Vault token for authentication")
 	rootCmd.PersistentFlags().StringVarP(&vaultAddr, "vault-addr", "a", "", "Vault server address")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json, yaml)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json, yaml, env)")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", vault.DefaultScanConcurrency, "Number of gates scanned in parallel")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Configuration file (default: ~/.gateplane/config.yaml, or under $XDG_CONFIG_HOME/gateplane)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Configuration profile to use for this command (default: the current profile)")
+	rootCmd.PersistentFlags().StringVar(&envShell, "shell", "", "Shell syntax for the env output format (bash, zsh, fish, powershell)")
 
 	rootCmd.AddCommand(
 		authCmd(),
@@ -53,14 +79,28 @@ func init() {
 		gatesCmd(),
 		requestCmd(),
 		approveCmd(),
+		rejectCmd(),
 		claimCmd(),
+		shellCmd(),
+		releaseCmd(),
+		revokeCmd(),
 		statusCmd(),
+		tokenHelperCmd(),
 		versionCmd(),
 	)
 }
 
 func main() {
+	if isTokenHelperBinary() {
+		rootCmd.SetArgs(append([]string{"token-helper"}, os.Args[1:]...))
+	}
+
 	if err := rootCmd.Execute(); err != nil {
+		// Pass through the exit code of commands run with claimed access
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,294 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/table"
+	"github.com/spf13/cobra"
+)
+
+// profileSummary describes a profile in 'config profile list'
+type profileSummary struct {
+	Name         string `json:"name" yaml:"name"`
+	Current      bool   `json:"current" yaml:"current"`
+	Active       bool   `json:"active" yaml:"active"`
+	VaultAddress string `json:"vault_address" yaml:"vault_address"`
+	Namespace    string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	DefaultGate  string `json:"default_gate,omitempty" yaml:"default_gate,omitempty"`
+	Aliases      int    `json:"aliases" yaml:"aliases"`
+	LoggedIn     bool   `json:"logged_in" yaml:"logged_in"`
+}
+
+func configProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile [profile]",
+		Short: "Manage configuration profiles",
+		Long: `Manage configuration profiles (contexts), each with its own Vault address, namespace,
+TLS settings, tokens, GatePlane Services login, gate aliases and defaults.
+The top-level settings of the configuration are the '` + config.DefaultProfile + `' profile.
+
+A profile is used until switching to another one, or for a single command
+with --profile (or ` + config.ProfileEnv + `). Its settings take precedence over
+VAULT_ADDR, VAULT_NAMESPACE and, once logged in, VAULT_TOKEN.`,
+		Example: `  gateplane config profile create staging --vault-address https://vault.staging:8200
+  gateplane config profile use staging
+  gateplane --profile production auth login --method oidc
+  gateplane --profile production gates list`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// 'config profile <name>' switches profile, as in older versions
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			return useProfile(args[0])
+		},
+	}
+
+	cmd.AddCommand(
+		configProfileCreateCmd(),
+		configProfileListCmd(),
+		configProfileUseCmd(),
+		configProfileCurrentCmd(),
+		configProfileRenameCmd(),
+		configProfileDeleteCmd(),
+	)
+
+	return cmd
+}
+
+func configProfileCreateCmd() *cobra.Command {
+	var (
+		from    string
+		use     bool
+		profile config.ProfileConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create [profile]",
+		Short: "Create a profile",
+		Long: `Create a profile, empty or copied from another profile with --from.
+Tokens are never copied, log in once the profile is in use.`,
+		Example: `  gateplane config profile create staging --vault-address https://vault.staging:8200 --ca-cert ./staging-ca.pem
+  gateplane config profile create production --from default --namespace prod --use`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			settings := config.ProfileConfig{}
+			if from != "" {
+				source, ok := config.GetProfile(from)
+				if !ok {
+					return fmt.Errorf("profile %s not found", from)
+				}
+				settings = source
+				settings.Gates = slices.Clone(source.Gates)
+			}
+
+			// Flags override the copied settings
+			flags := cmd.Flags()
+			// The global --vault-addr flag, otherwise ignored here, is taken as the address of the profile
+			if flags.Changed("vault-addr") {
+				if flags.Changed("vault-address") && profile.Vault.Address != vaultAddr {
+					return fmt.Errorf("--vault-addr and --vault-address differ, use --vault-address for the address of the profile")
+				}
+				profile.Vault.Address = vaultAddr
+				_ = flags.Set("vault-address", vaultAddr)
+			}
+			overrides := map[string]func(){
+				"vault-address":   func() { settings.Vault.Address = profile.Vault.Address },
+				"namespace":       func() { settings.Vault.Namespace = profile.Vault.Namespace },
+				"ca-cert":         func() { settings.Vault.CACert = profile.Vault.CACert },
+				"ca-path":         func() { settings.Vault.CAPath = profile.Vault.CAPath },
+				"client-cert":     func() { settings.Vault.ClientCert = profile.Vault.ClientCert },
+				"client-key":      func() { settings.Vault.ClientKey = profile.Vault.ClientKey },
+				"tls-server-name": func() { settings.Vault.TLSServerName = profile.Vault.TLSServerName },
+				"tls-skip-verify": func() { settings.Vault.TLSSkipVerify = profile.Vault.TLSSkipVerify },
+				"client-id":       func() { settings.Service.ClientID = profile.Service.ClientID },
+				"default-gate":    func() { settings.Defaults.Gate = profile.Defaults.Gate },
+				"output-format":   func() { settings.Defaults.OutputFormat = profile.Defaults.OutputFormat },
+			}
+			for flag, override := range overrides {
+				if flags.Changed(flag) {
+					override()
+				}
+			}
+
+			if format := settings.Defaults.OutputFormat; format != "" && format != OutputFormatTable &&
+				format != OutputFormatJSON && format != OutputFormatYAML {
+				return fmt.Errorf("invalid output format: %s. Must be one of: table, json, yaml", format)
+			}
+
+			if err := config.CreateProfile(name, settings); err != nil {
+				return wrapError("create profile", err)
+			}
+			printSuccessMessage("Created profile %s", name)
+
+			if use {
+				return useProfile(name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Copy the settings (not the tokens) of another profile")
+	cmd.Flags().BoolVar(&use, "use", false, "Switch to the profile once created")
+	cmd.Flags().StringVar(&profile.Vault.Address, "vault-address", "", "Vault address")
+	cmd.Flags().StringVar(&profile.Vault.Namespace, "namespace", "", "Vault namespace")
+	cmd.Flags().StringVar(&profile.Vault.CACert, "ca-cert", "", "CA certificate file to verify the Vault server")
+	cmd.Flags().StringVar(&profile.Vault.CAPath, "ca-path", "", "Directory of CA certificates to verify the Vault server")
+	cmd.Flags().StringVar(&profile.Vault.ClientCert, "client-cert", "", "Client certificate file for TLS authentication")
+	cmd.Flags().StringVar(&profile.Vault.ClientKey, "client-key", "", "Client key file for TLS authentication")
+	cmd.Flags().StringVar(&profile.Vault.TLSServerName, "tls-server-name", "", "Server name (SNI) of the Vault server")
+	cmd.Flags().BoolVar(&profile.Vault.TLSSkipVerify, "tls-skip-verify", false, "Do not verify the Vault server certificate (insecure)")
+	cmd.Flags().StringVar(&profile.Service.ClientID, "client-id", "", "GatePlane Services client ID")
+	cmd.Flags().StringVar(&profile.Defaults.Gate, "default-gate", "", "Default gate")
+	cmd.Flags().StringVar(&profile.Defaults.OutputFormat, "output-format", "", "Default output format (table, json, yaml)")
+
+	return cmd
+}
+
+func configProfileListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List profiles",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.GetConfig()
+			current := cfg.CurrentProfile
+			if current == "" {
+				current = config.DefaultProfile
+			}
+
+			var profiles []profileSummary
+			for _, name := range config.ProfileNames() {
+				profile, _ := config.GetProfile(name)
+				profiles = append(profiles, profileSummary{
+					Name:         name,
+					Current:      name == current,
+					Active:       name == config.ActiveProfile(),
+					VaultAddress: profile.Vault.Address,
+					Namespace:    profile.Vault.Namespace,
+					DefaultGate:  profile.Defaults.Gate,
+					Aliases:      len(profile.Gates),
+					LoggedIn:     config.StoredToken(name, profile.Vault.Address) != "",
+				})
+			}
+
+			format := getEffectiveOutputFormat()
+			if format == OutputFormatJSON || format == OutputFormatYAML {
+				return formatOutput(profiles, format)
+			}
+
+			rows := make([]table.Row, 0, len(profiles))
+			for _, profile := range profiles {
+				marker := ""
+				if profile.Active {
+					marker = "*"
+				}
+				rows = append(rows, table.Row{
+					marker,
+					profile.Name,
+					profile.VaultAddress,
+					profile.Namespace,
+					profile.DefaultGate,
+					fmt.Sprint(profile.Aliases),
+					formatYesNo(profile.LoggedIn),
+				})
+			}
+
+			table.RenderTable(table.TableOptions{
+				Headers: []string{"", "Profile", "Vault Address", "Namespace", "Default Gate", "Aliases", "Logged In"},
+				SortBy:  -1, // Default profile first
+				GroupBy: -1,
+			}, rows)
+
+			if current != config.ActiveProfile() {
+				fmt.Printf("Using %s for this command, the current profile is %s\n", config.ActiveProfile(), current)
+			}
+			return nil
+		},
+	}
+}
+
+func configProfileUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "use [profile]",
+		Aliases: []string{"switch"},
+		Short:   "Switch to a profile",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return useProfile(args[0])
+		},
+	}
+}
+
+func configProfileCurrentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Show the profile in use",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(config.ActiveProfile())
+			return nil
+		},
+	}
+}
+
+func configProfileRenameCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rename [profile] [new-name]",
+		Aliases: []string{"mv"},
+		Short:   "Rename a profile",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RenameProfile(args[0], args[1]); err != nil {
+				return wrapError("rename profile", err)
+			}
+			printSuccessMessage("Renamed profile %s to %s", args[0], args[1])
+			return nil
+		},
+	}
+}
+
+func configProfileDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete [profile]",
+		Aliases: []string{"rm"},
+		Short:   "Delete a profile and its stored tokens",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			current := config.GetConfig().CurrentProfile == name
+
+			if err := config.DeleteProfile(name); err != nil {
+				return wrapError("delete profile", err)
+			}
+			printSuccessMessage("Deleted profile %s", name)
+			if current {
+				fmt.Printf("Switched to profile: %s\n", config.DefaultProfile)
+			}
+			return nil
+		},
+	}
+}
+
+func useProfile(name string) error {
+	if err := config.UseProfile(name); err != nil {
+		return wrapError("use profile", err)
+	}
+	fmt.Printf("Switched to profile: %s\n", name)
+	return nil
+}
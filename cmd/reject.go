@@ -0,0 +1,129 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/service"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+func rejectCmd() *cobra.Command {
+	var (
+		interactive bool
+		reason      string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "reject [gate] [requestor-id]",
+		Aliases: []string{"deny"},
+		Short:   "Reject an access request",
+		Long:    "Reject access request using gate and request ID. If no arguments provided and running in TTY, enters interactive mode.",
+		Args:    cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useInteractive := isInteractiveMode(interactive, len(args) > 0, false)
+
+			if useInteractive {
+				return runInteractiveReject(reason)
+			}
+
+			// Non-interactive mode - require both arguments
+			if len(args) != 2 {
+				return fmt.Errorf("both gate and requestor-id are required in non-interactive mode")
+			}
+
+			gate := config.ResolveGatePath(args[0])
+			requestID := args[1]
+			return rejectRequest(requestID, gate, reason)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().StringVarP(&reason, "reason", "r", "", "Reason for rejecting the request")
+
+	return cmd
+}
+
+func runInteractiveReject(reason string) error {
+	client, err := createVaultClient()
+	if err != nil {
+		return wrapError("create vault client", err)
+	}
+
+	currentUser, err := client.GetSelf()
+	if err != nil {
+		return wrapError("get current user", err)
+	}
+
+	selectedRequest, err := selectPendingRequestInteractively(client, currentUser, "reject")
+	if err != nil || selectedRequest == nil {
+		return err
+	}
+
+	// The reason is optional, an empty input is accepted
+	if reason == "" {
+		reasonPrompt := promptui.Prompt{
+			Label: "Reason for rejection (optional)",
+		}
+		reason, err = reasonPrompt.Run()
+		if err != nil {
+			return fmt.Errorf("reason input cancelled: %w", err)
+		}
+	}
+
+	// Confirm rejection
+	confirmPrompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Reject request from %s on gate '%s'", selectedRequest.OwnerID, selectedRequest.Gate.Path),
+		IsConfirm: true,
+	}
+
+	_, err = confirmPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("rejection cancelled")
+	}
+
+	return rejectRequest(selectedRequest.OwnerID, selectedRequest.Gate.Path, reason)
+}
+
+func rejectRequest(requestID string, gate string, reason string) error {
+	client, err := createVaultClient()
+	if err != nil {
+		return wrapError("create vault client", err)
+	}
+
+	svcClient, err := createServiceClient()
+	if err != nil {
+		fmt.Println("Not authenticated with GatePlane Services (using Community Edition features)")
+		svcClient = nil
+	}
+
+	if err := client.RejectRequest(gate, requestID, reason); err != nil {
+		return wrapError("reject request", err)
+	}
+
+	// Send notification if service is authenticated
+	req, err := client.ListAllRequestsForGate(gate)
+	if err != nil {
+		return wrapError("list request status", err)
+	}
+
+	if err := sendNotificationWithRetry(svcClient, client, req[requestID], gate, service.Reject); err != nil {
+		return err
+	}
+
+	printSuccessMessage("Rejected request %s on gate: %s", requestID, gate)
+
+	return nil
+}
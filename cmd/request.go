@@ -12,8 +12,11 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/gateplane-io/client-cli/internal/config"
 	"github.com/gateplane-io/client-cli/internal/service"
@@ -23,8 +26,13 @@ import (
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
 	"github.com/gateplane-io/client-cli/pkg/models"
+
+	base "github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
 )
 
 func requestCmd() *cobra.Command {
@@ -38,7 +46,7 @@ func requestCmd() *cobra.Command {
 	cmd.AddCommand(
 		requestCreateCmd(),
 		requestListCmd(),
-		// requestCancelCmd(),
+		requestCancelCmd(),
 	)
 
 	return cmd
@@ -48,12 +56,14 @@ func requestCreateCmd() *cobra.Command {
 	var (
 		justification string
 		interactive   bool
+		ttl           time.Duration
 	)
 
 	cmd := &cobra.Command{
 		Use:     "create [gate]",
 		Aliases: []string{"c", "new", "add"},
 		Short:   "Create a new access request",
+		Long:    "Create a new access request. The requested access duration (--ttl) cannot exceed the maximum configured on the gate.",
 		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			useInteractive := isInteractiveMode(interactive, len(args) > 0, justification != "")
@@ -97,7 +107,28 @@ func requestCreateCmd() *cobra.Command {
 				}
 			}
 
-			if err := client.CreateRequest(gate, justification); err != nil {
+			lease, err := client.GetGateLeaseConfig(gate)
+			if err != nil {
+				// Vault validates the duration anyway, do not block the request
+				fmt.Fprintf(os.Stderr, "Warning: could not read gate duration limits: %v\n", err)
+				lease = nil
+			}
+
+			if ttl < 0 {
+				return fmt.Errorf("access duration cannot be negative")
+			}
+			if ttl == 0 && useInteractive {
+				ttl, err = selectDurationInteractively(lease)
+				if err != nil {
+					return err
+				}
+			}
+			if lease != nil && lease.LeaseMax > 0 && ttl > leaseDuration(lease.LeaseMax) {
+				return fmt.Errorf("requested duration %s exceeds the maximum of gate %s (%s)",
+					ttl, gate, leaseDuration(lease.LeaseMax))
+			}
+
+			if err := client.CreateRequest(gate, justification, ttl); err != nil {
 				return wrapError("create request", err)
 			}
 
@@ -120,6 +151,14 @@ func requestCreateCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&justification, "justification", "j", "", "Justification for access request")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Requested access duration (e.g. 15m, 1h), also --duration. Defaults to the gate's default duration")
+	// --duration is accepted as an alias of --ttl
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "duration" {
+			name = "ttl"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	return cmd
 }
@@ -178,18 +217,14 @@ func requestListCmd() *cobra.Command {
 			}
 
 			// Get requests from filtered gates
-
-			for _, gate := range targetGates {
-				gateRequests, err := client.ListAllRequestsForGate(gate.Path)
-				if err == nil && gateRequests != nil && len(gateRequests) > 0 {
-					for _, value := range gateRequests {
+			scans := scanGates(client, targetGates, vault.ScanOptions{Own: true, List: true})
+			for _, scan := range scans {
+				if len(scan.Requests) > 0 {
+					for _, value := range scan.Requests {
 						requests = append(requests, value)
 					}
-				} else {
-					gateRequest, err := client.GetRequestStatus(gate.Path)
-					if err == nil && gateRequest != nil {
-						requests = append(requests, gateRequest)
-					}
+				} else if scan.Own != nil {
+					requests = append(requests, scan.Own)
 				}
 			}
 
@@ -208,7 +243,7 @@ func requestListCmd() *cobra.Command {
 			for _, req := range requests {
 				rows = append(rows, table.Row{
 					formatGateDisplay(req.Path),
-					formatRequestStatus(req.Status),
+					formatRequestState(req),
 					req.OwnerID,
 					fmt.Sprintf("%d/%d", req.NumOfApprovals, req.RequiredApprovals),
 					req.Justification,
@@ -291,6 +326,84 @@ func selectGateInteractively(client *vault.Client, gates []*models.Gate) (string
 	return gates[selectedIndex].Path, nil
 }
 
+// durationChoices are the durations offered by the interactive duration picker
+var durationChoices = []time.Duration{
+	15 * time.Minute,
+	30 * time.Minute,
+	1 * time.Hour,
+	2 * time.Hour,
+	4 * time.Hour,
+	8 * time.Hour,
+}
+
+// leaseDuration converts a lease value (in seconds) returned by the gate to a time.Duration
+func leaseDuration(seconds float64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
+
+// selectDurationInteractively prompts the user for the access duration, showing the gate limits.
+// A zero duration means the gate's default duration.
+func selectDurationInteractively(lease *responses.ConfigLeaseResponse) (time.Duration, error) {
+	label := "Select access duration"
+	defaultItem := "Gate default"
+	var maxDuration time.Duration
+	if lease != nil {
+		maxDuration = leaseDuration(lease.LeaseMax)
+		label = fmt.Sprintf("%s (max: %s)", label, maxDuration)
+		defaultItem = fmt.Sprintf("%s (%s)", defaultItem, leaseDuration(lease.Lease))
+	}
+
+	items := []string{defaultItem}
+	durations := []time.Duration{0}
+	for _, d := range durationChoices {
+		if maxDuration > 0 && d > maxDuration {
+			continue
+		}
+		items = append(items, d.String())
+		durations = append(durations, d)
+	}
+	items = append(items, "Custom...")
+
+	prompt := promptui.Select{
+		Label: label,
+		Items: items,
+		Size:  len(items),
+	}
+
+	selectedIndex, _, err := prompt.Run()
+	if err != nil {
+		return 0, fmt.Errorf("duration selection cancelled: %w", err)
+	}
+
+	if selectedIndex < len(durations) {
+		return durations[selectedIndex], nil
+	}
+
+	customPrompt := promptui.Prompt{
+		Label: "Access duration (e.g. 45m, 1h30m)",
+		Validate: func(input string) error {
+			d, err := time.ParseDuration(strings.TrimSpace(input))
+			if err != nil {
+				return fmt.Errorf("invalid duration")
+			}
+			if d <= 0 {
+				return fmt.Errorf("duration must be positive")
+			}
+			if maxDuration > 0 && d > maxDuration {
+				return fmt.Errorf("duration exceeds the gate maximum (%s)", maxDuration)
+			}
+			return nil
+		},
+	}
+
+	result, err := customPrompt.Run()
+	if err != nil {
+		return 0, fmt.Errorf("duration input cancelled: %w", err)
+	}
+
+	return time.ParseDuration(strings.TrimSpace(result))
+}
+
 // promptForReason prompts the user to enter a reason for the access request
 func promptForReason() (string, error) {
 	validate := func(input string) error {
@@ -312,34 +425,142 @@ func promptForReason() (string, error) {
 	return result, nil
 }
 
-// Implement the DELETE endpoint for gate/request
 func requestCancelCmd() *cobra.Command {
+	var (
+		interactive bool
+		yes         bool
+	)
+
 	cmd := &cobra.Command{
-		Use:     "cancel [gate]",
+		Use:     "cancel [gate...]",
 		Aliases: []string{"delete", "rm"},
-		Short:   "Cancel your pending request on a gate",
-		Args:    cobra.MaximumNArgs(1),
+		Short:   "Cancel your pending or approved requests",
+		Long:    "Cancel your own Pending or Approved requests on one or more gates. If no gates are provided and running in TTY, enters interactive mode.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			gate, err := resolveGateFromArgs(args)
-			if err != nil {
-				return err
-			}
+			useInteractive := isInteractiveMode(interactive, len(args) > 0, false)
 
 			client, err := createVaultClient()
 			if err != nil {
 				return wrapError("create vault client", err)
 			}
 
-			path := fmt.Sprintf("%s/request", gate)
-			_, err = client.VaultClient().Logical().Delete(path)
+			svcClient, err := createServiceClient()
 			if err != nil {
-				return wrapError("cancel request", err)
+				fmt.Println("Not authenticated with GatePlane Services (using Community Edition features)")
+				svcClient = nil
+			}
+
+			var gates []string
+			if useInteractive {
+				gate, err := selectCancellableGateInteractively(client)
+				if err != nil {
+					return err
+				}
+				// Nothing to cancel
+				if gate == "" {
+					return nil
+				}
+				gates = append(gates, gate)
+			} else if len(args) > 0 {
+				for _, arg := range args {
+					gates = append(gates, config.ResolveGatePath(arg))
+				}
+			} else {
+				gate, err := resolveGateFromArgs(args)
+				if err != nil {
+					return err
+				}
+				gates = append(gates, gate)
+			}
+
+			if !yes {
+				if !term.IsTerminal(int(os.Stdin.Fd())) {
+					return fmt.Errorf("confirmation required. Use --yes to cancel without prompting")
+				}
+				confirmPrompt := promptui.Prompt{
+					Label:     fmt.Sprintf("Cancel your request on %s", strings.Join(gates, ", ")),
+					IsConfirm: true,
+				}
+				if _, err := confirmPrompt.Run(); err != nil {
+					return fmt.Errorf("cancellation aborted")
+				}
+			}
+
+			failed := 0
+			for _, gate := range gates {
+				if err := cancelRequest(client, svcClient, gate); err != nil {
+					printFailedMessage("%v", err)
+					failed++
+					continue
+				}
+				printSuccessMessage("Request cancelled on gate: %s", gate)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to cancel %d of %d requests", failed, len(gates))
 			}
 
-			printSuccessMessage("Request cancelled successfully")
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
 	return cmd
 }
+
+// cancelRequest cancels the caller's request on a single gate and notifies the approvers
+func cancelRequest(client *vault.Client, svcClient *service.Client, gate string) error {
+	req, err := client.GetRequestStatus(gate)
+	if err != nil {
+		return wrapError("get request status", err)
+	}
+
+	if req == nil {
+		return fmt.Errorf("no request found on gate %s", gate)
+	}
+
+	if req.Status != base.Pending && req.Status != base.Approved {
+		return fmt.Errorf("request on gate %s cannot be cancelled (status: %s)", gate, req.Status)
+	}
+
+	if err := client.CancelRequest(gate); err != nil {
+		return wrapError("cancel request", err)
+	}
+
+	// Let the approvers know that the request went away
+	return sendNotificationWithRetry(svcClient, client, req, gate, service.Cancel)
+}
+
+// selectCancellableGateInteractively lets the user pick one of their own Pending/Approved requests.
+// Returns an empty gate if there is nothing to cancel.
+func selectCancellableGateInteractively(client *vault.Client) (string, error) {
+	gates, err := client.DiscoverGates()
+	if err != nil {
+		return "", wrapError("discover gates", err)
+	}
+
+	return selectOwnRequestGateInteractively(client, gates, "No Pending or Approved Requests to cancel.",
+		base.Pending, base.Approved)
+}
+
+// selectOwnRequestGateInteractively lets the user pick one of the gates where
+// their own request is in one of the given statuses.
+// Returns an empty gate (after printing emptyMessage) if there is no such gate.
+func selectOwnRequestGateInteractively(client *vault.Client, gates []*models.Gate, emptyMessage string, statuses ...base.AccessRequestStatus) (string, error) {
+	var matchingGates []*models.Gate
+	for _, scan := range scanGates(client, gates, vault.ScanOptions{Own: true}) {
+		if scan.Own != nil && slices.Contains(statuses, scan.Own.Status) {
+			matchingGates = append(matchingGates, scan.Gate)
+		}
+	}
+
+	// No Request matches, do not proceed.
+	if len(matchingGates) == 0 {
+		color.New(color.Bold).Println(emptyMessage)
+		return "", nil
+	}
+
+	return selectGateInteractively(client, matchingGates)
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/gateplane-io/client-cli/internal/service"
+	"github.com/gateplane-io/client-cli/internal/vault"
+	"github.com/gateplane-io/client-cli/pkg/models"
+
+	base "github.com/gateplane-io/vault-plugins/pkg/models"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+func revokeCmd() *cobra.Command {
+	var (
+		interactive bool
+		reason      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revoke [gate] [requestor-id]",
+		Short: "Revoke active access granted to a requestor",
+		Long:  "Revoke claimed access using gate and request ID. If no arguments provided and running in TTY, enters interactive mode.",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useInteractive := isInteractiveMode(interactive, len(args) > 0, false)
+
+			if useInteractive {
+				return runInteractiveRevoke(reason)
+			}
+
+			// Non-interactive mode - require both arguments
+			if len(args) != 2 {
+				return fmt.Errorf("both gate and requestor-id are required in non-interactive mode")
+			}
+
+			gate := config.ResolveGatePath(args[0])
+			requestID := args[1]
+			return revokeAccess(requestID, gate, reason)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().StringVarP(&reason, "reason", "r", "", "Reason for revoking the access")
+
+	return cmd
+}
+
+func runInteractiveRevoke(reason string) error {
+	client, err := createVaultClient()
+	if err != nil {
+		return wrapError("create vault client", err)
+	}
+
+	currentUser, err := client.GetSelf()
+	if err != nil {
+		return wrapError("get current user", err)
+	}
+
+	gates, err := client.DiscoverGates()
+	if err != nil {
+		return wrapError("discover gates", err)
+	}
+
+	// Collect the active grants of others across all gates
+	var activeRequests []*models.Request
+	for _, scan := range scanGates(client, gates, vault.ScanOptions{List: true}) {
+		for _, req := range scan.Requests {
+			if req.Status == base.Active && req.OwnerID != currentUser.Entity.ID {
+				activeRequests = append(activeRequests, req)
+			}
+		}
+	}
+
+	if len(activeRequests) == 0 {
+		printSuccessMessage("No active access to revoke.")
+		fmt.Println("  • Your own access is not shown (use 'gateplane release')")
+		return nil
+	}
+
+	requestItems := make([]string, len(activeRequests))
+	for i, req := range activeRequests {
+		requestItems[i] = fmt.Sprintf("[%s] - (ID: %.8s) - %s",
+			req.Gate.Path,
+			req.OwnerID,
+			req.Justification,
+		)
+	}
+
+	selectedIndex, err := selectRequestItem("Select access to revoke", requestItems)
+	if err != nil {
+		return err
+	}
+
+	selectedRequest := activeRequests[selectedIndex]
+
+	// The reason is optional, an empty input is accepted
+	if reason == "" {
+		reasonPrompt := promptui.Prompt{
+			Label: "Reason for revocation (optional)",
+		}
+		reason, err = reasonPrompt.Run()
+		if err != nil {
+			return fmt.Errorf("reason input cancelled: %w", err)
+		}
+	}
+
+	confirmPrompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Revoke access of %s on gate '%s'", selectedRequest.OwnerID, selectedRequest.Gate.Path),
+		IsConfirm: true,
+	}
+
+	_, err = confirmPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("revocation cancelled")
+	}
+
+	return revokeAccess(selectedRequest.OwnerID, selectedRequest.Gate.Path, reason)
+}
+
+func revokeAccess(requestID string, gate string, reason string) error {
+	client, err := createVaultClient()
+	if err != nil {
+		return wrapError("create vault client", err)
+	}
+
+	svcClient, err := createServiceClient()
+	if err != nil {
+		fmt.Println("Not authenticated with GatePlane Services (using Community Edition features)")
+		svcClient = nil
+	}
+
+	if err := client.RevokeAccess(gate, requestID, reason); err != nil {
+		return wrapError("revoke access", err)
+	}
+
+	// Send notification if service is authenticated
+	req, err := client.ListAllRequestsForGate(gate)
+	if err != nil {
+		return wrapError("list request status", err)
+	}
+
+	if err := sendNotificationWithRetry(svcClient, client, req[requestID], gate, service.Revoke); err != nil {
+		return err
+	}
+
+	printSuccessMessage("Revoked access of %s on gate: %s", requestID, gate)
+
+	return nil
+}
+
+func releaseCmd() *cobra.Command {
+	var interactive bool
+
+	cmd := &cobra.Command{
+		Use:   "release [gate]",
+		Short: "Hand back your active access before it expires",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useInteractive := isInteractiveMode(interactive, len(args) > 0, false)
+
+			client, err := createVaultClient()
+			if err != nil {
+				return wrapError("create vault client", err)
+			}
+
+			svcClient, err := createServiceClient()
+			if err != nil {
+				fmt.Println("Not authenticated with GatePlane Services (using Community Edition features)")
+				svcClient = nil
+			}
+
+			var gate string
+			if useInteractive {
+				gates, err := client.DiscoverGates()
+				if err != nil {
+					return wrapError("discover gates", err)
+				}
+
+				gate, err = selectOwnRequestGateInteractively(client, gates, "No Active access to release.", base.Active)
+				if err != nil || gate == "" {
+					return err
+				}
+			} else {
+				gate, err = resolveGateFromArgs(args)
+				if err != nil {
+					return err
+				}
+			}
+
+			return releaseAccess(client, svcClient, gate)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+
+	return cmd
+}
+
+// releaseAccess releases the caller's active access on the gate and notifies the approvers
+func releaseAccess(client *vault.Client, svcClient *service.Client, gate string) error {
+	req, err := client.GetRequestStatus(gate)
+	if err != nil {
+		return wrapError("get request status", err)
+	}
+
+	if req == nil || req.Status != base.Active {
+		status := "none"
+		if req != nil {
+			status = req.Status.String()
+		}
+		return fmt.Errorf("no active access on gate %s (status: %s)", gate, status)
+	}
+
+	if err := client.ReleaseAccess(gate); err != nil {
+		return wrapError("release access", err)
+	}
+
+	if err := sendNotificationWithRetry(svcClient, client, req, gate, service.Release); err != nil {
+		return err
+	}
+
+	printSuccessMessage("Access released on gate: %s", gate)
+
+	return nil
+}
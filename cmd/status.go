@@ -15,6 +15,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/gateplane-io/client-cli/internal/table"
+	"github.com/gateplane-io/client-cli/internal/vault"
 	"github.com/gateplane-io/client-cli/pkg/models"
 	"github.com/spf13/cobra"
 
@@ -47,26 +48,25 @@ func statusCmd() *cobra.Command {
 			// Collect your requests
 			var myRequests []*models.Request
 			var pendingApprovals []*models.Request
+			var revokedRequests []*models.Request
 
-			for _, gate := range gates {
+			for _, scan := range scanGates(client, gates, vault.ScanOptions{Own: true, List: true}) {
 				// Check for your own requests
-				ownReq, err := client.GetRequestStatus(gate.Path)
-				if err == nil && ownReq != nil {
-					myRequests = append(myRequests, ownReq)
+				if scan.Own != nil {
+					myRequests = append(myRequests, scan.Own)
 				}
 
-				requests, err := client.ListAllRequestsForGate(gate.Path)
-				if err != nil {
-					// We are not "approvers" for this gate,
-					// and cannot see requests from others
-					continue
-				}
-
-				for _, req := range requests {
+				// If the requests cannot be listed, we are not "approvers"
+				// for this gate, and cannot see requests from others
+				for _, req := range scan.Requests {
 					// Check for pending approvals
 					if req.Status == base.Pending && req.OwnerID != currentUser.Entity.ID {
 						pendingApprovals = append(pendingApprovals, req)
 					}
+					// Check for access revoked before expiration
+					if req.Status == base.Revoked && req.OwnerID != currentUser.Entity.ID {
+						revokedRequests = append(revokedRequests, req)
+					}
 				}
 			}
 
@@ -88,7 +88,7 @@ func statusCmd() *cobra.Command {
 
 					rows = append(rows, table.Row{
 						formatGateDisplay(gatePath),
-						formatRequestStatus(req.Status),
+						formatRequestState(req),
 						req.Justification,
 					})
 				}
@@ -137,6 +137,33 @@ func statusCmd() *cobra.Command {
 				}
 			}
 
+			// Display revoked access
+			if len(revokedRequests) > 0 {
+				fmt.Println("\n" + color.CyanString("Revoked Access (on gates you approve):"))
+				rows := make([]table.Row, 0, len(revokedRequests))
+				for _, req := range revokedRequests {
+					revokedBy := req.RevokedBy
+					if revokedBy == "" {
+						revokedBy = "-"
+					} else if revokedBy == req.OwnerID {
+						revokedBy = "released by requestor"
+					}
+
+					rows = append(rows, table.Row{
+						formatGateDisplay(req.Gate.Path),
+						req.OwnerID,
+						revokedBy,
+						req.Justification,
+					})
+				}
+
+				table.RenderTable(table.TableOptions{
+					Headers: []string{"Gate", "Requestor ID", "Revoked By", "Justification"},
+					SortBy:  0, // Sort by Gate
+					GroupBy: 0, // Group by Gate
+				}, rows)
+			}
+
 			// Display claimable requests
 			fmt.Println("\n" + color.CyanString("Your Claimable Requests:"))
 			claimableRequests := make([]*models.Request, 0)
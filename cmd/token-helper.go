@@ -0,0 +1,141 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gateplane-io/client-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// The vault CLI runs its token helper with the operation as the only argument,
+// so the binary acts as 'gateplane token-helper' when installed (or linked) under this name
+const tokenHelperBinary = "gateplane-token-helper"
+
+func tokenHelperCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token-helper",
+		Short: "Act as the token helper of the vault CLI",
+		Long: `Act as a Vault token helper, so that the vault CLI and GatePlane share the same token.
+Tokens are kept in the credential store, per profile (--profile or GATEPLANE_PROFILE,
+falling back to the current profile) and Vault address (VAULT_ADDR, falling back
+to the address of the profile).
+
+The vault CLI runs its token helper without extra arguments, so link the binary as
+` + tokenHelperBinary + ` and set it in ~/.vault:
+  token_helper = "/usr/local/bin/` + tokenHelperBinary + `"`,
+		Example: `  ln -s "$(command -v gateplane)" /usr/local/bin/` + tokenHelperBinary + `
+  echo 'token_helper = "/usr/local/bin/` + tokenHelperBinary + `"' >> ~/.vault`,
+	}
+
+	cmd.AddCommand(
+		tokenHelperGetCmd(),
+		tokenHelperStoreCmd(),
+		tokenHelperEraseCmd(),
+	)
+
+	return cmd
+}
+
+func tokenHelperGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Print the stored token",
+		Args:  cobra.NoArgs,
+		// Errors are shown by the vault CLI
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			address, err := tokenHelperAddress()
+			if err != nil {
+				return err
+			}
+			if config.CredentialsLocked() {
+				return fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", config.PassphraseEnv)
+			}
+
+			// No token is not an error, the vault CLI then runs unauthenticated
+			fmt.Print(config.StoredToken(config.ActiveProfile(), address))
+			return nil
+		},
+	}
+}
+
+func tokenHelperStoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "store",
+		Short: "Store the token read from stdin",
+		Args:  cobra.NoArgs,
+		// Errors are shown by the vault CLI
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			address, err := tokenHelperAddress()
+			if err != nil {
+				return err
+			}
+
+			input, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return wrapError("read token", err)
+			}
+
+			token := strings.TrimSpace(string(input))
+			if _, err := config.StoreToken(config.ActiveProfile(), address, token); err != nil {
+				return wrapError("store token", err)
+			}
+			return nil
+		},
+	}
+}
+
+func tokenHelperEraseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "erase",
+		Short: "Erase the stored token",
+		Args:  cobra.NoArgs,
+		// Errors are shown by the vault CLI
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			address, err := tokenHelperAddress()
+			if err != nil {
+				return err
+			}
+
+			if _, err := config.StoreToken(config.ActiveProfile(), address, ""); err != nil {
+				return wrapError("erase token", err)
+			}
+			return nil
+		},
+	}
+}
+
+// tokenHelperAddress returns the Vault address the token is stored for,
+// preferring VAULT_ADDR as set by the vault CLI over the address of the profile
+func tokenHelperAddress() (string, error) {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		address = getVaultClientConfig().Address
+	}
+	if address == "" {
+		return "", fmt.Errorf("vault address not configured. Set VAULT_ADDR")
+	}
+	return address, nil
+}
+
+// isTokenHelperBinary reports whether the binary was run under the token helper name
+func isTokenHelperBinary() bool {
+	name := filepath.Base(os.Args[0])
+	return strings.TrimSuffix(name, filepath.Ext(name)) == tokenHelperBinary
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Outcomes of importing a gate alias
+const (
+	AliasAdded     = "added"
+	AliasUpdated   = "updated"
+	AliasUnchanged = "unchanged"
+	AliasConflict  = "conflict"
+	AliasReplaced  = "replaced"
+	AliasSkipped   = "skipped"
+)
+
+// ImportOptions select how conflicting gate aliases are imported.
+// A conflict is an alias pointing to another gate, or a gate with another alias.
+// Without Overwrite or Skip, nothing is imported if there are conflicts.
+type ImportOptions struct {
+	// Replace the conflicting aliases with the imported ones
+	Overwrite bool
+	// Keep the conflicting aliases, importing the others
+	Skip bool
+	// Report the outcome without saving
+	DryRun bool
+}
+
+// AliasImport is the outcome of importing a gate alias
+type AliasImport struct {
+	Alias  string `json:"alias" yaml:"alias"`
+	Path   string `json:"path" yaml:"path"`
+	Action string `json:"action" yaml:"action"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// GateAliases returns the aliased gates of the profile in use, including those of the project
+// configuration, optionally only those with any of the tags
+func GateAliases(tags ...string) []models.Gate {
+	var gates []models.Gate
+	for _, gate := range Gates() {
+		if gate.Alias == "" {
+			continue
+		}
+		if len(tags) > 0 && !slices.ContainsFunc(gate.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			continue
+		}
+		if isProjectGate(gate.Alias) {
+			gate.Source = models.SourceProject
+		} else {
+			gate.Source = models.SourceConfig
+		}
+		gates = append(gates, gate)
+	}
+	return gates
+}
+
+// RemoveGateAliases removes gate aliases, along with their gates, from the profile in use and saves the configuration
+func RemoveGateAliases(aliases []string) error {
+	err := withProfileSettings(ActiveProfile(), func(profile *ProfileConfig) error {
+		gates := slices.Clone(profile.Gates)
+		for _, alias := range aliases {
+			i := slices.IndexFunc(gates, func(gate models.Gate) bool { return gate.Alias == alias })
+			if i < 0 {
+				if isProjectGate(alias) {
+					return fmt.Errorf("gate alias %s is set by the project configuration %s", alias, projectFile)
+				}
+				return fmt.Errorf("gate with alias %s not found", alias)
+			}
+			gates = slices.Delete(gates, i, i+1)
+		}
+		profile.Gates = gates
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return SaveConfig()
+}
+
+// ExportGateAliases returns the aliased gates as a gate catalog, which can be imported
+func ExportGateAliases(tags ...string) ([]byte, error) {
+	catalog := GateCatalog{Gates: []models.Gate{}}
+	for _, gate := range GateAliases(tags...) {
+		gate.Source = ""
+		catalog.Gates = append(catalog.Gates, gate)
+	}
+	data, err := yaml.Marshal(catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal gate aliases: %w", err)
+	}
+	return data, nil
+}
+
+// ImportGateAliases merges the aliased gates of a gate catalog into the profile in use and saves the configuration.
+// Gates without an alias are left out. Returns the outcome for each alias.
+func ImportGateAliases(catalog *GateCatalog, options ImportOptions) ([]AliasImport, error) {
+	var result []AliasImport
+	conflicts := false
+
+	gates := slices.Clone(cfg.Gates)
+	for _, imported := range catalog.Gates {
+		if imported.Alias == "" {
+			continue
+		}
+		imported.Path = strings.Trim(imported.Path, "/")
+		imported.Source = ""
+		if imported.Path == "" {
+			return nil, fmt.Errorf("gate alias %s has no path", imported.Alias)
+		}
+		if imported.Type == "" {
+			imported.Type = models.PolicyGate
+		}
+		def, ok := models.LookupGateType(string(imported.Type))
+		if !ok {
+			return nil, fmt.Errorf("gate alias %s: unknown gate type %s. Must be one of: %s",
+				imported.Alias, imported.Type, strings.Join(models.GateTypeNames(), ", "))
+		}
+		imported.Type = def.Type
+
+		change := AliasImport{Alias: imported.Alias, Path: imported.Path}
+		byAlias := slices.IndexFunc(gates, func(gate models.Gate) bool { return gate.Alias == imported.Alias })
+		byPath := slices.IndexFunc(gates, func(gate models.Gate) bool { return gate.Path == imported.Path })
+
+		switch {
+		case byAlias < 0 && byPath < 0:
+			change.Action = AliasAdded
+			gates = append(gates, imported)
+		case byAlias >= 0 && byAlias == byPath:
+			if gateEqual(gates[byAlias], imported) {
+				change.Action = AliasUnchanged
+			} else {
+				change.Action = AliasUpdated
+				gates[byAlias] = imported
+			}
+		default:
+			if byAlias >= 0 {
+				change.Detail = fmt.Sprintf("@%s points to %s", imported.Alias, gates[byAlias].Path)
+			} else {
+				change.Detail = fmt.Sprintf("%s has the alias @%s", imported.Path, gates[byPath].Alias)
+			}
+			switch {
+			case options.Overwrite:
+				change.Action = AliasReplaced
+				// Both the gate with the alias and the gate with the path are replaced
+				gates = slices.DeleteFunc(gates, func(gate models.Gate) bool {
+					return gate.Alias == imported.Alias || gate.Path == imported.Path
+				})
+				gates = append(gates, imported)
+			case options.Skip:
+				change.Action = AliasSkipped
+			default:
+				change.Action = AliasConflict
+				conflicts = true
+			}
+		}
+		result = append(result, change)
+	}
+
+	if conflicts {
+		return result, fmt.Errorf("conflicting gate aliases, import with --overwrite to replace them or --skip to keep them")
+	}
+	if options.DryRun {
+		return result, nil
+	}
+
+	err := withProfileSettings(ActiveProfile(), func(profile *ProfileConfig) error {
+		profile.Gates = gates
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, SaveConfig()
+}
+
+func gateEqual(a, b models.Gate) bool {
+	return a.Path == b.Path && a.Alias == b.Alias && a.Type == b.Type &&
+		a.Description == b.Description && slices.Equal(a.Tags, b.Tags)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v3"
+)
+
+// GateCatalog is a file describing the gates of an organization,
+// used to find gates when they cannot be discovered from Vault
+type GateCatalog struct {
+	Gates []models.Gate `yaml:"gates"`
+}
+
+// LoadGateCatalog reads a gate catalog file
+func LoadGateCatalog(path string) (*GateCatalog, error) {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand catalog path: %w", err)
+	}
+
+	data, err := os.ReadFile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gate catalog: %w", err)
+	}
+
+	catalog := &GateCatalog{}
+	if err := yaml.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse gate catalog %s: %w", path, err)
+	}
+
+	return catalog, nil
+}
+
+// The configured gate catalog, read once per process
+var knownCatalog struct {
+	path    string
+	catalog *GateCatalog
+}
+
+// KnownGates returns the gates known from the configuration and the configured gate catalog.
+// The catalog is optional and read once per process: if it cannot be read, an error is returned
+// along with the configured gates the first time only.
+func KnownGates() ([]models.Gate, error) {
+	seen := map[string]bool{}
+	var gates []models.Gate
+
+	for _, gate := range Gates() {
+		if gate.Path == "" || seen[gate.Path] {
+			continue
+		}
+		gate.Source = models.SourceConfig
+		if gate.Alias != "" && isProjectGate(gate.Alias) {
+			gate.Source = models.SourceProject
+		}
+		gates = append(gates, gate)
+		seen[gate.Path] = true
+	}
+
+	if cfg.Catalog == "" {
+		return gates, nil
+	}
+
+	if knownCatalog.path != cfg.Catalog {
+		catalog, err := LoadGateCatalog(cfg.Catalog)
+		knownCatalog.path, knownCatalog.catalog = cfg.Catalog, catalog
+		if err != nil {
+			return gates, err
+		}
+	}
+	if knownCatalog.catalog == nil {
+		return gates, nil
+	}
+
+	for _, gate := range knownCatalog.catalog.Gates {
+		if gate.Path == "" || seen[gate.Path] {
+			continue
+		}
+		gate.Source = models.SourceCatalog
+		gates = append(gates, gate)
+		seen[gate.Path] = true
+	}
+
+	return gates, nil
+}
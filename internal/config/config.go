@@ -18,28 +18,46 @@ import (
 	"github.com/gateplane-io/client-cli/pkg/models"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // Config represents the main configuration structure for the GatePlane CLI
 type Config struct {
+	// Version of the configuration file, see ConfigVersion
+	Version  int                      `yaml:"version,omitempty"`
 	Vault    VaultConfig              `yaml:"vault"`
 	Service  ServiceConfig            `yaml:"service"`
 	Defaults DefaultsConfig           `yaml:"defaults"`
 	Gates    []models.Gate            `yaml:"gates"`
 	Profiles map[string]ProfileConfig `yaml:"profiles"`
+	// The profile in use, unless another one is selected with --profile
+	CurrentProfile string `mapstructure:"current_profile" yaml:"current_profile,omitempty"`
+	// Path to a gate catalog file, used when gates cannot be discovered from Vault
+	Catalog string `yaml:"catalog,omitempty"`
 }
 
 // VaultConfig contains Vault server connection settings
 type VaultConfig struct {
 	Address   string `yaml:"address"`
-	Token     string `yaml:"token"`
+	Token     string `yaml:"token,omitempty"` // Stored in the credential store
 	Namespace string `yaml:"namespace"`
+	// External Vault token helper, used instead of the credential store and ~/.vault-token
+	TokenHelper string `mapstructure:"token_helper" yaml:"token_helper,omitempty"`
+
+	// TLS settings, defaulting to VAULT_CACERT, VAULT_CAPATH, VAULT_CLIENT_CERT,
+	// VAULT_CLIENT_KEY, VAULT_TLS_SERVER_NAME and VAULT_SKIP_VERIFY
+	CACert        string `mapstructure:"ca_cert" yaml:"ca_cert,omitempty"`
+	CAPath        string `mapstructure:"ca_path" yaml:"ca_path,omitempty"`
+	ClientCert    string `mapstructure:"client_cert" yaml:"client_cert,omitempty"`
+	ClientKey     string `mapstructure:"client_key" yaml:"client_key,omitempty"`
+	TLSServerName string `mapstructure:"tls_server_name" yaml:"tls_server_name,omitempty"`
+	TLSSkipVerify bool   `mapstructure:"tls_skip_verify" yaml:"tls_skip_verify,omitempty"`
 }
 
 // ServiceConfig contains GatePlane service authentication settings
 type ServiceConfig struct {
 	ClientID string `mapstructure:"client_id" yaml:"client_id"`
-	JWT      string `yaml:"jwt"`
+	JWT      string `yaml:"jwt,omitempty"` // Stored in the credential store
 }
 
 var ServiceAddress = "https://backend.gateplane.io"
@@ -50,91 +68,227 @@ type DefaultsConfig struct {
 	OutputFormat string `mapstructure:"output_format" yaml:"output_format"`
 }
 
-// ProfileConfig contains settings for a specific configuration profile
-type ProfileConfig struct {
-	VaultAddress string `yaml:"vault_address"`
-	DefaultGate  string `yaml:"default_gate"`
-	Namespace    string `yaml:"namespace,omitempty"`
-}
+// ConfigEnv selects the configuration file, like --config
+const ConfigEnv = "GATEPLANE_CONFIG"
 
 var (
 	cfg        *Config
 	configFile string
-	credsFile  string
-	vaultFile  string
+	// The configuration file selected with --config
+	configFlag string
+	// The settings of the configuration file as last read or saved,
+	// to merge the changes made since into the file
+	fileBase  map[string]any
+	credsFile string
+	vaultFile string
+	// Set when the configuration file is newer than supported, which is then never saved
+	newerConfigErr error
 )
 
 // Init initializes the configuration system by creating config directory and loading config file
 func Init() error {
+	activeProfile = ""
+	overlay = map[string]overlayValue{}
+	newerConfigErr = nil
+
 	home, err := homedir.Dir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	configDir := filepath.Join(home, ".gateplane")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	var custom bool
+	configFile, custom, err = resolveConfigFile(home)
+	if err != nil {
+		return err
+	}
+	configDir := filepath.Dir(configFile)
+	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
+	// Directories created by older versions are readable by everyone
+	if info, err := os.Stat(configDir); err == nil && info.Mode().Perm()&0077 != 0 && !custom {
+		if err := os.Chmod(configDir, 0700); err != nil {
+			return fmt.Errorf("failed to restrict config directory permissions: %w", err)
+		}
+	}
 
-	configFile = filepath.Join(configDir, "config.yaml")
 	credsFile = filepath.Join(configDir, ".credentials.yaml")
 	// The file created by the 'vault login' command
 	vaultFile = filepath.Join(home, ".vault-token")
 
-	viper.SetConfigName("config")
+	viper.SetConfigFile(configFile)
 	viper.SetConfigType("yaml")
-	viper.AddConfigPath(configDir)
 
 	// Set defaults
 	viper.SetDefault("defaults.output_format", "table")
 
-	// Bind environment variables
-	viper.SetEnvPrefix("GATEPLANE")
-	viper.AutomaticEnv()
-
-	// Override with environment variables
-	if err := viper.BindEnv("vault.address", "VAULT_ADDR"); err != nil {
-		return fmt.Errorf("failed to bind vault.address env: %w", err)
-	}
-	if err := viper.BindEnv("vault.token", "VAULT_TOKEN"); err != nil {
-		return fmt.Errorf("failed to bind vault.token env: %w", err)
-	}
-	if err := viper.BindEnv("vault.namespace", "VAULT_NAMESPACE"); err != nil {
-		return fmt.Errorf("failed to bind vault.namespace env: %w", err)
-	}
-
-	// Try to read config file
+	// Try to read config file. The environment is not read by viper,
+	// but overlaid on the profile in use, so that it is never saved.
+	exists := true
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+		if !os.IsNotExist(err) {
 			return fmt.Errorf("failed to read config file: %w", err)
 		}
 		// Config file not found; create default config
-		cfg = &Config{
-			Defaults: DefaultsConfig{
-				OutputFormat: "table",
-			},
-		}
-		return SaveConfig()
+		exists = false
 	}
 
 	cfg = &Config{}
 	if err := viper.Unmarshal(cfg); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	// Profiles without settings are left out by viper
+	for name := range viper.GetStringMap("profiles") {
+		if _, ok := cfg.Profiles[name]; !ok {
+			if cfg.Profiles == nil {
+				cfg.Profiles = map[string]ProfileConfig{}
+			}
+			cfg.Profiles[name] = ProfileConfig{}
+		}
+	}
+	settings, err := fileSettings()
+	if err != nil {
+		return err
+	}
+	fileBase = decodeSettings(settings)
+
+	if err := loadCredentials(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := loadProjectConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring the project configuration: %v\n", err)
+	}
+	version, migrated, err := migrateConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		if version > ConfigVersion {
+			// Saving would drop the settings unknown to this version
+			newerConfigErr = err
+		}
+	}
+	if err := migrateLegacyCredentials(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to migrate the stored tokens: %v\n", err)
+	}
+
+	// Secrets come from the credential store, unless overridden by the environment
+	if err := activateProfile(cfg.CurrentProfile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using the default profile\n", err)
+	}
 
-	// If the ~/.vault-token contains a token
-	// it takes priority over the hardcoded one
-	_, exists := os.LookupEnv("VAULT_TOKEN")
 	if !exists {
-		vaultFileToken, err := ReadVaultFile()
+		// Written in full
+		cfg.Version = ConfigVersion
+		fileBase = nil
+		return SaveConfig()
+	}
+	if migrated {
+		backup, err := backupConfig(version)
 		if err == nil {
-			cfg.Vault.Token = vaultFileToken
+			err = SaveConfig()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to migrate the configuration: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Migrated %s from version %d to %d, the previous version is kept as %s\n",
+				configFile, version, ConfigVersion, backup)
 		}
 	}
 
 	return nil
 }
 
+// resolveSecrets sets the secrets in use for the active profile
+func resolveSecrets() {
+	resolveVaultToken()
+	cfg.Service.JWT = storedServiceJWT(activeProfile)
+
+	if credsLocked {
+		lockedToken, lockedJWT = cfg.Vault.Token, cfg.Service.JWT
+	}
+}
+
+// resolveVaultToken sets the Vault token in use: the one stored for the active profile and Vault address,
+// overridden by VAULT_TOKEN or, when it is not set, by ~/.vault-token.
+// The token of a selected profile takes priority over both, and is the only one used
+// for the Vault address of a project configuration.
+// With an external token helper configured, the token is left to the helper.
+// Tokens of the environment and ~/.vault-token are overlaid, never stored.
+func resolveVaultToken() {
+	delete(overlay, "vault.token")
+
+	stored := ""
+	if cfg.Vault.TokenHelper == "" {
+		stored = StoredToken(activeProfile, cfg.Vault.Address)
+	}
+	cfg.Vault.Token = stored
+	if (activeProfile != "" && stored != "") || ProjectVaultAddress() {
+		return
+	}
+
+	token, origin := "", ""
+	if envToken, exists := os.LookupEnv("VAULT_TOKEN"); exists {
+		token, origin = envToken, "env VAULT_TOKEN"
+	} else if cfg.Vault.TokenHelper == "" {
+		// If the ~/.vault-token contains a token
+		// it takes priority over the stored one
+		if vaultFileToken, err := ReadVaultFile(); err == nil {
+			token, origin = vaultFileToken, vaultFile
+		}
+	}
+	if origin == "" {
+		return
+	}
+
+	cfg.Vault.Token = token
+	overlay["vault.token"] = overlayValue{value: token, fileValue: stored, origin: origin}
+}
+
+// GetConfigFile returns the path of the configuration file
+func GetConfigFile() string {
+	return configFile
+}
+
+// SetConfigFile selects the configuration file to use instead of the default one, before Init
+func SetConfigFile(path string) {
+	configFlag = path
+}
+
+// resolveConfigFile returns the path of the configuration file: the one selected with --config
+// or GATEPLANE_CONFIG (custom), ~/.gateplane/config.yaml if it exists, or under the XDG config directory
+// ($XDG_CONFIG_HOME/gateplane, ~/.config/gateplane) if it is set or exists, ~/.gateplane/config.yaml otherwise
+func resolveConfigFile(home string) (string, bool, error) {
+	path := configFlag
+	if path == "" {
+		path = os.Getenv(ConfigEnv)
+	}
+	if path != "" {
+		expanded, err := homedir.Expand(path)
+		if err == nil {
+			expanded, err = filepath.Abs(expanded)
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		return expanded, true, nil
+	}
+
+	legacyDir := filepath.Join(home, ".gateplane")
+	if _, err := os.Stat(legacyDir); err == nil {
+		return filepath.Join(legacyDir, "config.yaml"), false, nil
+	}
+
+	xdgHome := os.Getenv("XDG_CONFIG_HOME")
+	xdgDir := filepath.Join(xdgHome, "gateplane")
+	if xdgHome == "" || !filepath.IsAbs(xdgHome) {
+		xdgDir = filepath.Join(home, ".config", "gateplane")
+	}
+	if _, err := os.Stat(xdgDir); err == nil || (xdgHome != "" && filepath.IsAbs(xdgHome)) {
+		return filepath.Join(xdgDir, "config.yaml"), false, nil
+	}
+
+	return filepath.Join(legacyDir, "config.yaml"), false, nil
+}
+
 // GetConfig returns the current configuration, initializing it if necessary
 func GetConfig() *Config {
 	if cfg == nil {
@@ -146,56 +300,160 @@ func GetConfig() *Config {
 	return cfg
 }
 
-// SaveConfig saves the current configuration to disk
+// SaveConfig saves the current configuration to disk.
+// Secrets are saved to the credential store instead of the configuration file.
+// Concurrent gateplane processes save in turn: the changes made since the configuration was read
+// are merged into the file on disk, which is replaced atomically.
 func SaveConfig() error {
-	viper.Set("vault", cfg.Vault)
-	viper.Set("service", cfg.Service)
-	viper.Set("defaults", cfg.Defaults)
-	viper.Set("gates", cfg.Gates)
-	viper.Set("profiles", cfg.Profiles)
+	return withLock(saveConfig)
+}
 
-	return viper.WriteConfigAs(configFile)
+func saveConfig() error {
+	if newerConfigErr != nil {
+		return fmt.Errorf("refusing to save the configuration: %w", newerConfigErr)
+	}
+	if credsLocked {
+		if cfg.Vault.Token != lockedToken || cfg.Service.JWT != lockedJWT {
+			return fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", PassphraseEnv)
+		}
+	} else {
+		// Tokens are kept by the external token helper, when configured
+		if cfg.Vault.TokenHelper == "" {
+			setStoredToken(activeProfile, cfg.Vault.Address, fileValue("vault.token", cfg.Vault.Token))
+		}
+		setStoredServiceJWT(activeProfile, cfg.Service.JWT)
+		if err := saveCredentials(); err != nil {
+			return err
+		}
+	}
+
+	ours, err := fileSettings()
+	if err != nil {
+		return err
+	}
+	doc, err := readConfigDocument()
+	if err != nil {
+		return err
+	}
+	hasVersion := mappingValue(doc.root, "version") != nil
+	mergeMapping(doc.root, fileBase, ours, false)
+	doc.dropSecrets()
+	if !hasVersion {
+		doc.moveFirst("version")
+	}
+
+	data, err := doc.encode()
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(configFile, data, 0644); err != nil {
+		return err
+	}
+	fileBase = decodeSettings(ours)
+	return nil
+}
+
+// fileSettings returns the settings to save to the configuration file, as a YAML mapping:
+// those of the active profile are saved to the profile, the default profile's at the top level
+func fileSettings() (*yaml.Node, error) {
+	settings := withoutOverlay(currentSettings())
+	profiles := make(map[string]ProfileConfig, len(cfg.Profiles))
+	for name, profile := range cfg.Profiles {
+		profiles[name] = profile.withoutSecrets()
+	}
+	if activeProfile != "" {
+		profiles[activeProfile] = settings.withoutSecrets()
+		settings = baseSettings
+	}
+	settings = settings.withoutSecrets()
+
+	var node yaml.Node
+	err := node.Encode(Config{
+		Version:        cfg.Version,
+		Vault:          settings.Vault,
+		Service:        settings.Service,
+		Defaults:       settings.Defaults,
+		Gates:          settings.Gates,
+		Profiles:       profiles,
+		CurrentProfile: cfg.CurrentProfile,
+		Catalog:        fileValue("catalog", cfg.Catalog),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return &node, nil
+}
+
+// decodeSettings returns the settings of a YAML mapping, by key
+func decodeSettings(node *yaml.Node) map[string]any {
+	var values map[string]any
+	_ = node.Decode(&values)
+	return values
 }
 
 // SetVaultAddress updates the Vault address in configuration and saves it
 func SetVaultAddress(address string) error {
 	cfg.Vault.Address = address
+	delete(overlay, "vault.address")
+	// Tokens are stored per Vault address
+	resolveVaultToken()
 	return SaveConfig()
 }
 
 // SetVaultToken updates the Vault token in configuration and saves it
 func SetVaultToken(token string) error {
 	cfg.Vault.Token = token
+	delete(overlay, "vault.token")
+	return SaveConfig()
+}
+
+// SetVaultLogin updates the Vault token of a login in configuration, along with the Vault address
+// and namespace given for the login (unless empty), and saves them
+func SetVaultLogin(address, namespace, token string) error {
+	if address != "" {
+		cfg.Vault.Address = address
+		delete(overlay, "vault.address")
+	}
+	if namespace != "" {
+		cfg.Vault.Namespace = namespace
+		delete(overlay, "vault.namespace")
+	}
+	cfg.Vault.Token = token
+	delete(overlay, "vault.token")
 	return SaveConfig()
 }
 
 // SetDefaultGate updates the default gate in configuration and saves it
 func SetDefaultGate(gate string) error {
 	cfg.Defaults.Gate = gate
+	delete(overlay, "defaults.gate")
 	return SaveConfig()
 }
 
-// AddGateAlias adds or updates a gate alias in configuration and saves it
-func AddGateAlias(path, alias string, gateType models.GateType) error {
-	for i, gate := range cfg.Gates {
-		if gate.Path == path {
-			cfg.Gates[i].Alias = alias
+// AddGateAlias adds or updates a gate alias in configuration and saves it.
+// The description and tags of a gate already configured are kept, unless given.
+func AddGateAlias(gate models.Gate) error {
+	for i, existing := range cfg.Gates {
+		if existing.Path == gate.Path {
+			cfg.Gates[i].Alias = gate.Alias
+			if gate.Description != "" {
+				cfg.Gates[i].Description = gate.Description
+			}
+			if len(gate.Tags) > 0 {
+				cfg.Gates[i].Tags = gate.Tags
+			}
 			return SaveConfig()
 		}
 	}
 
-	cfg.Gates = append(cfg.Gates, models.Gate{
-		Path:  path,
-		Alias: alias,
-		Type:  gateType,
-	})
+	cfg.Gates = append(cfg.Gates, gate)
 
 	return SaveConfig()
 }
 
 // GetGateByAlias retrieves a gate configuration by its alias
 func GetGateByAlias(alias string) (*models.Gate, error) {
-	for _, gate := range cfg.Gates {
+	for _, gate := range Gates() {
 		if gate.Alias == alias {
 			return &gate, nil
 		}
@@ -214,7 +472,7 @@ func ResolveGatePath(gateRef string) string {
 	}
 
 	// Check if it's a known gate path
-	for _, gate := range cfg.Gates {
+	for _, gate := range Gates() {
 		if gate.Path == gateRef || gate.Alias == gateRef {
 			return gate.Path
 		}
@@ -224,26 +482,6 @@ func ResolveGatePath(gateRef string) string {
 	return gateRef
 }
 
-// UseProfile switches to the specified configuration profile and saves the changes
-func UseProfile(profileName string) error {
-	profile, ok := cfg.Profiles[profileName]
-	if !ok {
-		return fmt.Errorf("profile %s not found", profileName)
-	}
-
-	if profile.VaultAddress != "" {
-		cfg.Vault.Address = profile.VaultAddress
-	}
-	if profile.DefaultGate != "" {
-		cfg.Defaults.Gate = profile.DefaultGate
-	}
-	if profile.Namespace != "" {
-		cfg.Vault.Namespace = profile.Namespace
-	}
-
-	return SaveConfig()
-}
-
 // SetServiceJWT updates the service JWT token in configuration and saves it
 func SetServiceJWT(jwt string) error {
 	cfg.Service.JWT = jwt
@@ -253,6 +491,7 @@ func SetServiceJWT(jwt string) error {
 // SetServiceClientID updates the service client ID in configuration and saves it
 func SetServiceClientID(clientID string) error {
 	cfg.Service.ClientID = clientID
+	delete(overlay, "service.client_id")
 	return SaveConfig()
 }
 
@@ -262,6 +501,90 @@ func ClearServiceAuth() error {
 	return SaveConfig()
 }
 
+// VaultFilePath returns the path of the token file written by 'vault login'
+func VaultFilePath() string {
+	return vaultFile
+}
+
+// plaintextSecrets returns the secrets written into the configuration file by older versions,
+// ignoring the environment and the vault token file
+func plaintextSecrets() (Credentials, error) {
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	if err := v.ReadInConfig(); err != nil {
+		if os.IsNotExist(err) {
+			return Credentials{}, nil
+		}
+		return Credentials{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return Credentials{
+		VaultToken: v.GetString("vault.token"),
+		ServiceJWT: v.GetString("service.jwt"),
+	}, nil
+}
+
+// migratePlaintextSecrets moves secrets found in the configuration file to the credential store,
+// to be saved by the caller. Secrets already in the store take priority.
+func migratePlaintextSecrets() error {
+	plaintext, err := plaintextSecrets()
+	if err != nil || (plaintext.VaultToken == "" && plaintext.ServiceJWT == "") {
+		return err
+	}
+	if credsLocked {
+		return fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", PassphraseEnv)
+	}
+
+	if creds.VaultToken == "" && StoredToken(DefaultProfile, cfg.Vault.Address) == "" {
+		creds.VaultToken = plaintext.VaultToken
+	}
+	if creds.ServiceJWT == "" && storedServiceJWT(DefaultProfile) == "" {
+		creds.ServiceJWT = plaintext.ServiceJWT
+	}
+	return nil
+}
+
+// migrateLegacyCredentials keys the single tokens stored by older versions
+// by the default profile (and Vault address)
+func migrateLegacyCredentials() error {
+	if (creds.VaultToken == "" && creds.ServiceJWT == "") || credsLocked {
+		return nil
+	}
+	if creds.VaultToken != "" && StoredToken(DefaultProfile, cfg.Vault.Address) == "" {
+		setStoredToken(DefaultProfile, cfg.Vault.Address, creds.VaultToken)
+	}
+	if creds.ServiceJWT != "" && storedServiceJWT(DefaultProfile) == "" {
+		setStoredServiceJWT(DefaultProfile, creds.ServiceJWT)
+	}
+	creds.VaultToken = ""
+	creds.ServiceJWT = ""
+	return saveCredentials()
+}
+
+// ClearVaultToken removes the Vault token of the active profile and Vault address from the credential store.
+// Reports whether the store held a token.
+func ClearVaultToken() (bool, error) {
+	// Also erased when an external token helper keeps the tokens in use
+	stored := !credsLocked && setStoredToken(activeProfile, cfg.Vault.Address, "")
+
+	cfg.Vault.Token = ""
+	if err := SaveConfig(); err != nil {
+		return false, err
+	}
+	return stored, nil
+}
+
+// RemoveVaultFile deletes the token file written by 'vault login'.
+// Reports whether the file existed.
+func RemoveVaultFile() (bool, error) {
+	if err := os.Remove(vaultFile); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to remove vault token file: %w", err)
+	}
+	return true, nil
+}
+
 // ReadVaultFile reads the contents of the vault token file
 func ReadVaultFile() (string, error) {
 	if vaultFile == "" {
@@ -0,0 +1,468 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Credentials holds the secrets kept out of config.yaml
+type Credentials struct {
+	// Vault tokens by profile and Vault address (see TokenKey),
+	// shared with the vault CLI through 'gateplane token-helper'
+	VaultTokens map[string]string `yaml:"vault_tokens,omitempty"`
+	// GatePlane Services tokens by profile
+	ServiceJWTs map[string]string `yaml:"service_jwts,omitempty"`
+	// The single tokens stored by older versions, moved to VaultTokens and ServiceJWTs on load
+	VaultToken string `yaml:"vault_token,omitempty"`
+	ServiceJWT string `yaml:"service_jwt,omitempty"`
+}
+
+// Credential store backends
+const (
+	CredentialsPlaintext = "plaintext"
+	CredentialsEncrypted = "encrypted"
+)
+
+// PassphraseEnv holds the passphrase of an encrypted credential store, for non-interactive use
+const PassphraseEnv = "GATEPLANE_CREDENTIALS_PASSPHRASE"
+
+// The encryption scheme of the credential store
+const (
+	credentialsCipher     = "aes-256-gcm"
+	credentialsKDF        = "pbkdf2-sha256"
+	credentialsIterations = 600000
+)
+
+// encryptedCredentials is the on-disk format of an encrypted credential store
+type encryptedCredentials struct {
+	Cipher     string `yaml:"cipher"`
+	KDF        string `yaml:"kdf"`
+	Iterations int    `yaml:"iterations"`
+	// Base64-encoded
+	Salt  string `yaml:"salt"`
+	Nonce string `yaml:"nonce"`
+	Data  string `yaml:"data"`
+}
+
+var (
+	creds Credentials
+	// The credentials as last read or saved, to merge the changes made since into the store
+	credsBase    Credentials
+	credsBackend = CredentialsPlaintext
+	// Set when the store is encrypted and no passphrase was available,
+	// so that it is not overwritten with empty secrets
+	credsLocked bool
+	// The secrets in use when the store was found locked (from the environment or ~/.vault-token),
+	// which are not worth saving
+	lockedToken string
+	lockedJWT   string
+	passphrase  string
+
+	passphraseFunc func() (string, error)
+)
+
+// SetPassphraseFunc sets how the passphrase of an encrypted credential store is asked for,
+// when it is not provided through GATEPLANE_CREDENTIALS_PASSPHRASE
+func SetPassphraseFunc(fn func() (string, error)) {
+	passphraseFunc = fn
+}
+
+// CredentialsFilePath returns the path of the credential store
+func CredentialsFilePath() string {
+	return credsFile
+}
+
+// StoredCredentials returns the secrets in the credential store
+func StoredCredentials() Credentials {
+	return creds
+}
+
+// TokenKey returns the key of the Vault token of a profile and Vault address in the credential store
+func TokenKey(profile, address string) string {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return profile + "@" + strings.TrimSuffix(strings.TrimSpace(address), "/")
+}
+
+// StoredToken returns the Vault token stored for the profile and Vault address
+func StoredToken(profile, address string) string {
+	return creds.VaultTokens[TokenKey(profile, address)]
+}
+
+// StoreToken saves the Vault token of the profile and Vault address to the credential store.
+// An empty token erases it. Reports whether a token was stored before.
+func StoreToken(profile, address, token string) (bool, error) {
+	if credsLocked {
+		return false, fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", PassphraseEnv)
+	}
+
+	existed := setStoredToken(profile, address, token)
+	return existed, saveCredentials()
+}
+
+// setStoredToken sets the Vault token of the profile and Vault address in memory.
+// Reports whether a token was stored before.
+func setStoredToken(profile, address, token string) bool {
+	key := TokenKey(profile, address)
+	_, existed := creds.VaultTokens[key]
+
+	if token == "" {
+		delete(creds.VaultTokens, key)
+		return existed
+	}
+	if creds.VaultTokens == nil {
+		creds.VaultTokens = map[string]string{}
+	}
+	creds.VaultTokens[key] = token
+	return existed
+}
+
+// storedServiceJWT returns the GatePlane Services token stored for the profile
+func storedServiceJWT(profile string) string {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return creds.ServiceJWTs[profile]
+}
+
+// setStoredServiceJWT sets the GatePlane Services token of the profile in memory, an empty token erases it
+func setStoredServiceJWT(profile, jwt string) {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	if jwt == "" {
+		delete(creds.ServiceJWTs, profile)
+		return
+	}
+	if creds.ServiceJWTs == nil {
+		creds.ServiceJWTs = map[string]string{}
+	}
+	creds.ServiceJWTs[profile] = jwt
+}
+
+// renameProfileCredentials moves the tokens of a profile to its new name, an empty name erases them.
+// Reports whether the profile had any token.
+func renameProfileCredentials(profile, newName string) bool {
+	found := false
+
+	prefix := TokenKey(profile, "")
+	for key, token := range creds.VaultTokens {
+		address, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		delete(creds.VaultTokens, key)
+		if newName != "" {
+			creds.VaultTokens[TokenKey(newName, address)] = token
+		}
+		found = true
+	}
+
+	if jwt, ok := creds.ServiceJWTs[profile]; ok {
+		delete(creds.ServiceJWTs, profile)
+		if newName != "" {
+			creds.ServiceJWTs[newName] = jwt
+		}
+		found = true
+	}
+
+	return found
+}
+
+// CredentialsLocked reports whether the credential store is encrypted and could not be unlocked
+func CredentialsLocked() bool {
+	return credsLocked
+}
+
+// CredentialsBackend returns whether the credential store is plaintext or encrypted
+func CredentialsBackend() string {
+	return credsBackend
+}
+
+// getPassphrase returns the passphrase of the encrypted store, asking for it once per run
+func getPassphrase() (string, error) {
+	if passphrase != "" {
+		return passphrase, nil
+	}
+	if env := os.Getenv(PassphraseEnv); env != "" {
+		passphrase = env
+		return passphrase, nil
+	}
+	if passphraseFunc == nil {
+		return "", fmt.Errorf("the credential store is encrypted, set %s to unlock it", PassphraseEnv)
+	}
+	input, err := passphraseFunc()
+	if err != nil {
+		return "", err
+	}
+	passphrase = input
+	return passphrase, nil
+}
+
+// loadCredentials reads the credential store, decrypting it if needed
+func loadCredentials() error {
+	creds = Credentials{}
+	credsBase = Credentials{}
+	credsBackend = CredentialsPlaintext
+	credsLocked = false
+
+	stored, backend, err := readCredentials()
+	credsBackend = backend
+	if err != nil {
+		credsLocked = backend == CredentialsEncrypted
+		return err
+	}
+
+	creds = stored
+	credsBase = stored.clone()
+	return nil
+}
+
+// readCredentials reads and decrypts the credential store, returning its backend
+func readCredentials() (Credentials, string, error) {
+	var stored Credentials
+
+	data, err := os.ReadFile(credsFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stored, CredentialsPlaintext, nil
+		}
+		return stored, CredentialsPlaintext, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	backend := CredentialsPlaintext
+	var envelope encryptedCredentials
+	if err := yaml.Unmarshal(data, &envelope); err == nil && envelope.Cipher != "" {
+		backend = CredentialsEncrypted
+		pass, err := getPassphrase()
+		if err != nil {
+			return stored, backend, err
+		}
+		data, err = decryptCredentials(&envelope, pass)
+		if err != nil {
+			passphrase = ""
+			return stored, backend, err
+		}
+	}
+
+	if err := yaml.Unmarshal(data, &stored); err != nil {
+		return stored, backend, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	return stored, backend, nil
+}
+
+// clone returns a copy of the credentials, not sharing their maps
+func (c Credentials) clone() Credentials {
+	c.VaultTokens = maps.Clone(c.VaultTokens)
+	c.ServiceJWTs = maps.Clone(c.ServiceJWTs)
+	return c
+}
+
+// saveCredentials writes the credential store with mode 0600, replacing it atomically.
+// The tokens changed since it was read are merged into the store on disk.
+func saveCredentials() error {
+	if credsLocked {
+		return fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", PassphraseEnv)
+	}
+
+	return withLock(func() error {
+		if err := mergeCredentials(); err != nil {
+			return err
+		}
+		return writeCredentials()
+	})
+}
+
+// mergeCredentials merges the tokens changed since the store was read into the store on disk,
+// which another gateplane process may have changed since. To be called holding the lock.
+func mergeCredentials() error {
+	disk, _, err := readCredentials()
+	if err != nil {
+		return err
+	}
+	creds.VaultTokens = mergeSecrets(credsBase.VaultTokens, creds.VaultTokens, disk.VaultTokens)
+	creds.ServiceJWTs = mergeSecrets(credsBase.ServiceJWTs, creds.ServiceJWTs, disk.ServiceJWTs)
+	return nil
+}
+
+// writeCredentials writes the credential store with its backend. To be called holding the lock.
+func writeCredentials() error {
+	data, err := yaml.Marshal(&creds)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	if credsBackend == CredentialsEncrypted {
+		pass, err := getPassphrase()
+		if err != nil {
+			return err
+		}
+		envelope, err := encryptCredentials(data, pass)
+		if err != nil {
+			return err
+		}
+		if data, err = yaml.Marshal(envelope); err != nil {
+			return fmt.Errorf("failed to marshal credentials: %w", err)
+		}
+	}
+
+	if err := writeFileAtomic(credsFile, data, 0600); err != nil {
+		return err
+	}
+	credsBase = creds.clone()
+	return nil
+}
+
+// writeFileAtomic writes a file through a temporary file and a rename,
+// so that it is never found partially written
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	// CreateTemp uses 0600, set the mode regardless of the umask
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// SetCredentialsBackend re-writes the credential store as plaintext or encrypted with a new passphrase
+func SetCredentialsBackend(backend string, newPassphrase string) error {
+	if credsLocked {
+		return fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", PassphraseEnv)
+	}
+
+	switch backend {
+	case CredentialsPlaintext:
+	case CredentialsEncrypted:
+		if newPassphrase == "" {
+			return fmt.Errorf("a passphrase is required to encrypt the credential store")
+		}
+	default:
+		return fmt.Errorf("unsupported credential store backend: %s. Must be one of: %s, %s",
+			backend, CredentialsPlaintext, CredentialsEncrypted)
+	}
+
+	return withLock(func() error {
+		// Merged with the current passphrase, before re-writing with the new one
+		if err := mergeCredentials(); err != nil {
+			return err
+		}
+		credsBackend = backend
+		if backend == CredentialsEncrypted {
+			passphrase = newPassphrase
+		}
+		return writeCredentials()
+	})
+}
+
+// deriveKey derives the AES-256 key of the credential store from the passphrase
+func deriveKey(pass string, salt []byte, iterations int) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, pass, salt, iterations, 32)
+}
+
+func encryptCredentials(plaintext []byte, pass string) (*encryptedCredentials, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newCredentialsGCM(pass, salt, credentialsIterations)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return &encryptedCredentials{
+		Cipher:     credentialsCipher,
+		KDF:        credentialsKDF,
+		Iterations: credentialsIterations,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Data:       base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, plaintext, nil)),
+	}, nil
+}
+
+func decryptCredentials(envelope *encryptedCredentials, pass string) ([]byte, error) {
+	if envelope.Cipher != credentialsCipher || envelope.KDF != credentialsKDF {
+		return nil, fmt.Errorf("unsupported credential store encryption: %s/%s", envelope.Cipher, envelope.KDF)
+	}
+
+	salt, saltErr := base64.StdEncoding.DecodeString(envelope.Salt)
+	nonce, nonceErr := base64.StdEncoding.DecodeString(envelope.Nonce)
+	data, dataErr := base64.StdEncoding.DecodeString(envelope.Data)
+	if err := errors.Join(saltErr, nonceErr, dataErr); err != nil {
+		return nil, fmt.Errorf("corrupted credential store: %w", err)
+	}
+
+	gcm, err := newCredentialsGCM(pass, salt, envelope.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("corrupted credential store: invalid nonce")
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credentials: wrong passphrase?")
+	}
+	return plaintext, nil
+}
+
+func newCredentialsGCM(pass string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := deriveKey(pass, salt, iterations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Indentation of new configuration files, as written by older versions
+const defaultIndent = 4
+
+// configDocument is the configuration file as a YAML document, along with the layout
+// that the YAML encoder does not keep
+type configDocument struct {
+	node *yaml.Node
+	// The mapping of the settings
+	root *yaml.Node
+	// Indentation of the file
+	indent int
+	// Top-level keys preceded by a blank line
+	spaced map[string]bool
+}
+
+// readConfigDocument reads the configuration file as a YAML document, empty if it does not exist
+func readConfigDocument() (*configDocument, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	doc := &configDocument{
+		node:   &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}},
+		root:   root,
+		indent: defaultIndent,
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return doc, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(node.Content) > 0 {
+		if node.Content[0].Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("failed to parse config file: %s is not a mapping of settings", configFile)
+		}
+		doc.node, doc.root = &node, node.Content[0]
+	}
+
+	lines := strings.Split(string(data), "\n")
+	doc.indent = detectIndent(lines)
+	doc.spaced = spacedKeys(lines)
+	return doc, nil
+}
+
+// encode returns the document in YAML, with the indentation and blank lines of the file
+func (doc *configDocument) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(doc.indent)
+	if err := encoder.Encode(doc.node); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	var out []string
+	for _, line := range lines {
+		if key, ok := topLevelKey(line); ok && doc.spaced[key] {
+			// Before the comments of the key
+			start := len(out)
+			for start > 0 && strings.HasPrefix(out[start-1], "#") {
+				start--
+			}
+			if start > 0 && out[start-1] != "" {
+				out = append(out[:start], append([]string{""}, out[start:]...)...)
+			}
+		}
+		out = append(out, line)
+	}
+	return []byte(strings.Join(out, "\n") + "\n"), nil
+}
+
+// dropSecrets removes the secrets written into the configuration file by older versions,
+// moved to the credential store
+func (doc *configDocument) dropSecrets() {
+	settings := []*yaml.Node{doc.root}
+	if profiles := mappingValue(doc.root, "profiles"); profiles != nil && profiles.Kind == yaml.MappingNode {
+		for i := 1; i < len(profiles.Content); i += 2 {
+			settings = append(settings, profiles.Content[i])
+		}
+	}
+
+	for _, s := range settings {
+		if vault := mappingValue(s, "vault"); vault != nil {
+			removeMappingKey(vault, "token")
+		}
+		if service := mappingValue(s, "service"); service != nil {
+			removeMappingKey(service, "jwt")
+		}
+	}
+}
+
+// moveFirst moves a top-level key to the top of the file, below the comment heading the file
+func (doc *configDocument) moveFirst(key string) {
+	content := doc.root.Content
+	for i := 2; i+1 < len(content); i += 2 {
+		if content[i].Value == key {
+			// The comment of the first key, unless separated by a blank line, heads the file
+			if content[i].HeadComment == "" {
+				content[i].HeadComment, content[0].HeadComment = content[0].HeadComment, ""
+			}
+			pair := []*yaml.Node{content[i], content[i+1]}
+			doc.root.Content = append(pair, append(content[:i:i], content[i+2:]...)...)
+			return
+		}
+	}
+}
+
+// detectIndent returns the indentation of the first nested key of the file
+func detectIndent(lines []string) int {
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if indent == 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+		if indent >= 2 && indent <= 8 {
+			return indent
+		}
+		break
+	}
+	return defaultIndent
+}
+
+// spacedKeys returns the top-level keys preceded by a blank line (before their comments)
+func spacedKeys(lines []string) map[string]bool {
+	spaced := map[string]bool{}
+	for i, line := range lines {
+		key, ok := topLevelKey(line)
+		if !ok {
+			continue
+		}
+		j := i - 1
+		for j >= 0 && strings.HasPrefix(lines[j], "#") {
+			j--
+		}
+		if j >= 0 && strings.TrimSpace(lines[j]) == "" {
+			spaced[key] = true
+		}
+	}
+	return spaced
+}
+
+// topLevelKey returns the key of a line starting a top-level setting
+func topLevelKey(line string) (string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '#' || line[0] == '-' {
+		return "", false
+	}
+	key, _, found := strings.Cut(line, ":")
+	return strings.Trim(key, `"'`), found
+}
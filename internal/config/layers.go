@@ -0,0 +1,219 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// The settings in effect are those of the configuration file (and credential store),
+// overlaid with the settings of the project configuration and of the environment
+// for the current command only.
+// The overlay is never saved: unless changed since, the value of the file is saved instead.
+
+// Origins of the settings in effect, other than files and environment variables
+const (
+	OriginDefault     = "default"
+	OriginCredentials = "credential store"
+	OriginTokenHelper = "token helper"
+)
+
+// overlayValue is a setting in effect for the current command only
+type overlayValue struct {
+	value string
+	// The value of the configuration file, saved instead
+	fileValue string
+	origin    string
+}
+
+// The overlay of the settings of the active profile, by key
+var overlay = map[string]overlayValue{}
+
+// Environment variables of the Vault CLI, used for the settings of the Vault connection
+var vaultEnvSettings = map[string]string{
+	"vault.address":   "VAULT_ADDR",
+	"vault.namespace": "VAULT_NAMESPACE",
+}
+
+// SettingEnv returns the environment variable overriding a setting, e.g. GATEPLANE_VAULT_ADDRESS
+func SettingEnv(key string) string {
+	return "GATEPLANE_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// applyOverlay puts the settings of the environment and of the project over those of the active profile.
+// The GATEPLANE_* variables take priority. The variables of the Vault CLI, then the project
+// configuration, override the default profile, but only fill in the empty settings of a selected profile.
+func applyOverlay() {
+	overlay = map[string]overlayValue{}
+
+	settings := currentSettings()
+	fromProject := projectSettings()
+	for _, setting := range Settings() {
+		ref := settingRef{setting: setting}
+		current := ref.value(&settings)
+
+		value, origin := overlaySource(ref, current, &fromProject)
+		if value == "" || value == current {
+			continue
+		}
+
+		if err := ref.set(&settings, value); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring %s: %v\n", strings.TrimPrefix(origin, "env "), err)
+			continue
+		}
+		overlay[setting.Key] = overlayValue{
+			value:     ref.value(&settings),
+			fileValue: current,
+			origin:    origin,
+		}
+	}
+	setSettings(settings)
+}
+
+// overlaySource returns the value of the environment or of the project put over a setting
+// of the active profile, and where it comes from, or an empty value if there is none
+func overlaySource(ref settingRef, current string, fromProject *ProfileConfig) (string, string) {
+	env := SettingEnv(ref.setting.Key)
+	value, origin := os.Getenv(env), "env "+env
+	if vaultEnv, ok := vaultEnvSettings[ref.setting.Key]; ok && value == "" && (activeProfile == "" || current == "") {
+		value, origin = os.Getenv(vaultEnv), "env "+vaultEnv
+	}
+	// Unset (or false) settings of the project are left out
+	if projectValue := ref.value(fromProject); value == "" && !ref.setting.Global && projectValue != "false" &&
+		(activeProfile == "" || current == "") {
+		value, origin = projectValue, projectFile
+	}
+	return value, origin
+}
+
+// SettingOverride reports whether the saved value of a configuration key is overridden,
+// for the profile in use, by the environment or the project configuration, and by which
+func SettingOverride(key string) (string, bool) {
+	ref, err := parseSettingKey(key)
+	if err != nil || ref.profile != ActiveProfile() {
+		return "", false
+	}
+	if ref.alias != "" {
+		return projectFile, isProjectGate(ref.alias)
+	}
+
+	profile, _ := GetProfile(ref.profile)
+	saved := ref.value(&profile)
+	fromProject := projectSettings()
+	value, origin := overlaySource(ref, saved, &fromProject)
+	return origin, value != "" && value != saved
+}
+
+// clearOverlay restores the settings of the configuration file, before switching profiles.
+// Returns the settings of the active profile.
+func clearOverlay() ProfileConfig {
+	settings := withoutOverlay(currentSettings())
+	for _, setting := range Settings() {
+		if setting.Global {
+			ref := settingRef{setting: setting}
+			_ = ref.set(nil, fileValue(setting.Key, ref.value(nil)))
+		}
+	}
+	overlay = map[string]overlayValue{}
+	return settings
+}
+
+// withoutOverlay returns the settings of the active profile to save,
+// with the overlaid settings that have not changed since restored
+func withoutOverlay(settings ProfileConfig) ProfileConfig {
+	for _, setting := range Settings() {
+		if setting.Global {
+			continue
+		}
+		if _, ok := overlay[setting.Key]; ok {
+			ref := settingRef{setting: setting}
+			_ = ref.set(&settings, fileValue(setting.Key, ref.value(&settings)))
+		}
+	}
+	return settings
+}
+
+// fileValue returns the value of a setting to save: the value of the configuration file,
+// if the setting in effect is overlaid and unchanged
+func fileValue(key, value string) string {
+	if ov, ok := overlay[key]; ok && ov.value == value {
+		return ov.fileValue
+	}
+	return value
+}
+
+// ProjectVaultAddress reports whether the Vault address in use is set by the project configuration.
+// Only the token stored for the profile and address is used for it, as the project is not trusted
+// with the tokens of the environment.
+func ProjectVaultAddress() bool {
+	ov, ok := overlay["vault.address"]
+	return ok && projectFile != "" && ov.origin == projectFile
+}
+
+// SettingOrigins returns where the value in effect of each setting of the active profile comes from:
+// the configuration file, an environment variable, the credential store or the defaults
+func SettingOrigins() map[string]string {
+	origins := map[string]string{}
+
+	settings := currentSettings()
+	for _, setting := range Settings() {
+		ref := settingRef{setting: setting}
+		origins[setting.Key] = settingOrigin(setting.Key, ref.value(&settings), setting.Global)
+	}
+	for _, gate := range Gates() {
+		if gate.Alias == "" {
+			continue
+		}
+		origins[AliasKeyPrefix+gate.Alias] = fileOrigin(false)
+		if isProjectGate(gate.Alias) {
+			origins[AliasKeyPrefix+gate.Alias] = projectFile
+		}
+	}
+
+	switch ov, ok := overlay["vault.token"]; {
+	case ok:
+		origins["vault.token"] = ov.origin
+	case cfg.Vault.TokenHelper != "":
+		origins["vault.token"] = OriginTokenHelper + " " + cfg.Vault.TokenHelper
+	case cfg.Vault.Token != "":
+		origins["vault.token"] = OriginCredentials
+	}
+	if cfg.Service.JWT != "" {
+		origins["service.jwt"] = OriginCredentials
+	}
+
+	return origins
+}
+
+func settingOrigin(key, value string, global bool) string {
+	if ov, ok := overlay[key]; ok {
+		return ov.origin
+	}
+	if value == "" || value == "false" {
+		return OriginDefault
+	}
+	// Defaults are saved along with the settings, once anything is saved
+	if (activeProfile == "" || global) && !viper.InConfig(key) {
+		return OriginDefault
+	}
+	return fileOrigin(global)
+}
+
+func fileOrigin(global bool) string {
+	if activeProfile == "" || global {
+		return configFile
+	}
+	return fmt.Sprintf("%s (profile %s)", configFile, activeProfile)
+}
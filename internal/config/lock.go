@@ -0,0 +1,65 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// How long to wait for another gateplane process to finish writing the configuration
+const (
+	lockTimeout       = 10 * time.Second
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+// Nesting depth of withLock, as the lock is held by the process
+var lockDepth int
+
+// withLock runs fn holding the lock of the configuration directory, shared by the configuration
+// file and the credential store, so that concurrent gateplane processes write them in turn.
+// Nested calls run under the lock already held.
+func withLock(fn func() error) error {
+	if lockDepth > 0 {
+		lockDepth++
+		defer func() { lockDepth-- }()
+		return fn()
+	}
+
+	path := filepath.Join(filepath.Dir(configFile), ".lock")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open lock file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			return fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for another gateplane process to release %s", path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+	defer func() { _ = unlockFile(f) }()
+
+	lockDepth++
+	defer func() { lockDepth-- }()
+	return fn()
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"maps"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Another gateplane process may save the configuration (or the credential store) between
+// the moment it is read and the moment it is saved. Saving re-reads the file under the lock
+// and merges into it only the changes made since reading it, instead of overwriting it.
+//
+// The configuration file is merged as a YAML document, so that the keys left unchanged,
+// their order, comments and keys unknown to this version are kept as they are.
+
+// mergeMapping applies the changes from base to ours onto a mapping read from disk, in place:
+// key by key in nested mappings, and gate by gate in gate lists. Other values are replaced
+// as a whole. Empty values are removed rather than written, except for the entries of keepEmpty
+// mappings (profiles).
+func mergeMapping(disk *yaml.Node, base map[string]any, ours *yaml.Node, keepEmpty bool) {
+	for i := 0; i+1 < len(ours.Content); i += 2 {
+		key, oursValue := ours.Content[i].Value, ours.Content[i+1]
+
+		var value any
+		_ = oursValue.Decode(&value)
+		baseValue, inBase := base[key]
+		if inBase && reflect.DeepEqual(baseValue, value) {
+			// Unchanged, keep the value on disk
+			continue
+		}
+
+		diskValue := mappingValue(disk, key)
+		switch {
+		case diskValue != nil && diskValue.Kind == yaml.MappingNode && oursValue.Kind == yaml.MappingNode:
+			baseMap, _ := baseValue.(map[string]any)
+			mergeMapping(diskValue, baseMap, oursValue, key == "profiles")
+		case key == "gates" && diskValue != nil && diskValue.Kind == yaml.SequenceNode && oursValue.Kind == yaml.SequenceNode:
+			baseGates, _ := baseValue.([]any)
+			mergeGates(diskValue, baseGates, oursValue)
+		default:
+			setMappingValue(disk, key, pruneValue(key, oursValue), keepEmpty)
+		}
+	}
+
+	for key := range base {
+		if mappingValue(ours, key) == nil {
+			removeMappingKey(disk, key)
+		}
+	}
+}
+
+// mergeGates applies the changes from base to ours onto a gate list read from disk, in place,
+// identifying gates by path
+func mergeGates(disk *yaml.Node, base []any, ours *yaml.Node) {
+	baseGates := map[string]map[string]any{}
+	for _, item := range base {
+		if gate, ok := item.(map[string]any); ok {
+			if path, ok := gate["path"].(string); ok {
+				baseGates[path] = gate
+			}
+		}
+	}
+
+	oursPaths := map[string]bool{}
+	for _, item := range ours.Content {
+		path := gatePath(item)
+		oursPaths[path] = true
+
+		var value map[string]any
+		_ = item.Decode(&value)
+		baseGate, inBase := baseGates[path]
+		if inBase && reflect.DeepEqual(baseGate, value) {
+			continue
+		}
+
+		if diskGate := findGate(disk, path); diskGate != nil {
+			mergeMapping(diskGate, baseGate, item, false)
+		} else if gate := pruneNode(item); gate != nil {
+			if len(disk.Content) == 0 {
+				disk.Style &^= yaml.FlowStyle
+			}
+			disk.Content = append(disk.Content, gate)
+		}
+	}
+
+	content := disk.Content[:0]
+	for _, item := range disk.Content {
+		if _, inBase := baseGates[gatePath(item)]; !inBase || oursPaths[gatePath(item)] {
+			content = append(content, item)
+		}
+	}
+	disk.Content = content
+	// Written as '[]' rather than left without a value
+	if len(disk.Content) == 0 {
+		disk.Style = yaml.FlowStyle
+	}
+}
+
+func gatePath(gate *yaml.Node) string {
+	if path := mappingValue(gate, "path"); path != nil {
+		return path.Value
+	}
+	return ""
+}
+
+func findGate(gates *yaml.Node, path string) *yaml.Node {
+	for _, gate := range gates.Content {
+		if gate.Kind == yaml.MappingNode && gatePath(gate) == path {
+			return gate
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of a key of a mapping node, keeping the comments of the value it replaces.
+// A nil value removes the key, or is written as an empty mapping with keepEmpty.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node, keepEmpty bool) {
+	if value == nil {
+		if !keepEmpty {
+			removeMappingKey(node, key)
+			return
+		}
+		value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Style: yaml.FlowStyle}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		old := node.Content[i+1]
+		if value.HeadComment == "" {
+			value.HeadComment = old.HeadComment
+		}
+		if value.LineComment == "" {
+			value.LineComment = old.LineComment
+		}
+		if value.FootComment == "" {
+			value.FootComment = old.FootComment
+		}
+		node.Content[i+1] = value
+		return
+	}
+
+	// An empty mapping written as '{}' is no longer empty
+	if len(node.Content) == 0 {
+		node.Style &^= yaml.FlowStyle
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// removeMappingKey removes a key, and its value, from a mapping node
+func removeMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// pruneNode returns a copy of the node without empty values (empty strings, false, empty lists and mappings),
+// or nil if nothing is left
+func pruneNode(node *yaml.Node) *yaml.Node {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" || (node.Tag == "!!str" && node.Value == "") || (node.Tag == "!!bool" && node.Value == "false") {
+			return nil
+		}
+		return node
+	case yaml.MappingNode:
+		pruned := *node
+		pruned.Content = nil
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if value := pruneValue(node.Content[i].Value, node.Content[i+1]); value != nil {
+				pruned.Content = append(pruned.Content, node.Content[i], value)
+			}
+		}
+		if len(pruned.Content) == 0 {
+			return nil
+		}
+		return &pruned
+	case yaml.SequenceNode:
+		pruned := *node
+		pruned.Content = nil
+		for _, item := range node.Content {
+			if item := pruneNode(item); item != nil {
+				pruned.Content = append(pruned.Content, item)
+			}
+		}
+		if len(pruned.Content) == 0 {
+			return nil
+		}
+		return &pruned
+	}
+	return node
+}
+
+// pruneValue prunes the value of a key, see pruneNode
+func pruneValue(key string, value *yaml.Node) *yaml.Node {
+	if key == "profiles" && value.Kind == yaml.MappingNode {
+		return pruneProfiles(value)
+	}
+	return pruneNode(value)
+}
+
+// pruneProfiles prunes the settings of profiles, keeping the profiles without settings
+func pruneProfiles(node *yaml.Node) *yaml.Node {
+	pruned := *node
+	pruned.Content = nil
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		profile := pruneNode(node.Content[i+1])
+		if profile == nil {
+			profile = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Style: yaml.FlowStyle}
+		}
+		pruned.Content = append(pruned.Content, node.Content[i], profile)
+	}
+	if len(pruned.Content) == 0 {
+		return nil
+	}
+	return &pruned
+}
+
+// mergeSecrets applies the changes from base to ours onto the secrets read from disk
+func mergeSecrets(base, ours, disk map[string]string) map[string]string {
+	merged := maps.Clone(disk)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+
+	for key, value := range ours {
+		if baseValue, ok := base[key]; !ok || baseValue != value {
+			merged[key] = value
+		}
+	}
+	for key := range base {
+		if _, ok := ours[key]; !ok {
+			delete(merged, key)
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
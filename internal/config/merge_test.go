@@ -0,0 +1,203 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v3"
+)
+
+func parseMapping(t *testing.T, data string) *yaml.Node {
+	t.Helper()
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(data), &node); err != nil {
+		t.Fatalf("failed to parse %q: %v", data, err)
+	}
+	if len(node.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	return node.Content[0]
+}
+
+func TestMergeMapping(t *testing.T) {
+	tests := []struct {
+		name string
+		// The file as read, as saved since by another process, and as changed by this one
+		base, disk, ours string
+		want             string
+	}{
+		{
+			name: "add/add",
+			base: "vault:\n  address: https://vault:8200\n",
+			disk: "vault:\n  address: https://vault:8200\ndefaults:\n  gate: gates/a\n",
+			ours: "vault:\n  address: https://vault:8200\n  namespace: team-a\n",
+			want: "vault:\n  address: https://vault:8200\n  namespace: team-a\ndefaults:\n  gate: gates/a\n",
+		},
+		{
+			name: "add/add of the same key, ours wins",
+			base: "vault:\n  address: https://vault:8200\n",
+			disk: "vault:\n  address: https://vault:8200\n  namespace: team-b\n",
+			ours: "vault:\n  address: https://vault:8200\n  namespace: team-a\n",
+			want: "vault:\n  address: https://vault:8200\n  namespace: team-a\n",
+		},
+		{
+			name: "edit/remove",
+			base: "vault:\n  address: https://vault:8200\n  namespace: team-a\n",
+			disk: "vault:\n  address: https://vault:8200\n",
+			ours: "vault:\n  address: https://vault:8200\n  namespace: team-b\n",
+			want: "vault:\n  address: https://vault:8200\n  namespace: team-b\n",
+		},
+		{
+			name: "remove/edit",
+			base: "vault:\n  address: https://vault:8200\n  namespace: team-a\n",
+			disk: "vault:\n  address: https://vault:8200\n  namespace: team-b\n",
+			ours: "vault:\n  address: https://vault:8200\n",
+			want: "vault:\n  address: https://vault:8200\n",
+		},
+		{
+			name: "unchanged keys keep the value on disk",
+			base: "vault:\n  address: https://vault:8200\n",
+			disk: "# Edited by hand\nvault:\n  address: https://other:8200 # moved\n",
+			ours: "vault:\n  address: https://vault:8200\n",
+			want: "# Edited by hand\nvault:\n  address: https://other:8200 # moved\n",
+		},
+		{
+			name: "gates added by both",
+			base: "gates:\n  - path: gates/a\n    alias: a\n",
+			disk: "gates:\n  - path: gates/a\n    alias: a\n  - path: gates/b\n    alias: b\n",
+			ours: "gates:\n  - path: gates/a\n    alias: a\n  - path: gates/c\n    alias: c\n",
+			want: "gates:\n  - path: gates/a\n    alias: a\n  - path: gates/b\n    alias: b\n  - path: gates/c\n    alias: c\n",
+		},
+		{
+			name: "gate removed while another is edited",
+			base: "gates:\n  - path: gates/a\n    alias: a\n  - path: gates/b\n    alias: b\n",
+			disk: "gates:\n  - path: gates/a\n    alias: a\n  - path: gates/b\n    alias: b2\n",
+			ours: "gates:\n  - path: gates/b\n    alias: b\n",
+			want: "gates:\n  - path: gates/b\n    alias: b2\n",
+		},
+		{
+			name: "gate edited by both, ours wins",
+			base: "gates:\n  - path: gates/a\n    alias: a\n",
+			disk: "gates:\n  - path: gates/a\n    alias: disk\n",
+			ours: "gates:\n  - path: gates/a\n    alias: ours\n",
+			want: "gates:\n  - path: gates/a\n    alias: ours\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disk := parseMapping(t, tt.disk)
+			mergeMapping(disk, decodeSettings(parseMapping(t, tt.base)), parseMapping(t, tt.ours), false)
+
+			doc := &configDocument{node: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{disk}}, root: disk, indent: 2}
+			got, err := doc.encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("merged:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrationKeepsComments(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(ConfigEnv, "")
+	t.Setenv(PassphraseEnv, "")
+	for _, env := range []string{"VAULT_ADDR", "VAULT_TOKEN", "VAULT_NAMESPACE"} {
+		// Restored after the test
+		t.Setenv(env, "")
+		os.Unsetenv(env)
+	}
+	t.Chdir(home)
+	homedir.DisableCache = true
+	t.Cleanup(func() { homedir.DisableCache = false })
+
+	configFile := filepath.Join(home, ".gateplane", "config.yaml")
+	original := `# Team config template
+
+vault:
+    address: https://vault.example.com:8200 # production
+    token: hvs.secret
+
+# Shared gates
+gates:
+    - path: gates/production/ssh
+      alias: prod-ssh
+      type: policy
+`
+	if err := os.MkdirAll(filepath.Dir(configFile), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configFile, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated := string(data)
+	for _, want := range []string{
+		"# Team config template\n",
+		"address: https://vault.example.com:8200 # production\n",
+		"\n# Shared gates\ngates:\n",
+		"alias: prod-ssh\n",
+	} {
+		if !strings.Contains(migrated, want) {
+			t.Errorf("migrated file lost %q:\n%s", want, migrated)
+		}
+	}
+	if !strings.HasPrefix(migrated, "# Team config template\n") {
+		t.Errorf("migrated file does not start with its comment:\n%s", migrated)
+	}
+	if !strings.Contains(migrated, "version: 2\n") {
+		t.Errorf("migrated file has no version:\n%s", migrated)
+	}
+	if strings.Contains(migrated, "hvs.secret") {
+		t.Errorf("migrated file still has the token:\n%s", migrated)
+	}
+
+	backup, err := os.ReadFile(configFile + ".v1.bak")
+	if err != nil {
+		t.Fatalf("no backup of version 1: %v", err)
+	}
+	if string(backup) != original {
+		t.Errorf("backup differs from the original:\n%s", backup)
+	}
+
+	// Read again, the migrated file is left as it is
+	if err := Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	data, err = os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != migrated {
+		t.Errorf("migrated file changed when read again:\n%s\nwas:\n%s", data, migrated)
+	}
+	if got := GetConfig().Vault.Token; got != "hvs.secret" {
+		t.Errorf("token after migration = %q, want it from the credential store", got)
+	}
+}
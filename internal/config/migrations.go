@@ -0,0 +1,90 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// ConfigVersion is the version of the configuration file written by this version of the CLI.
+// Configuration files without a version are of version 1.
+const ConfigVersion = 2
+
+// configMigration upgrades the configuration, in memory, from the previous version to its version
+type configMigration struct {
+	version     int
+	description string
+	migrate     func() error
+}
+
+// The migrations of the configuration, in order
+var configMigrations = []configMigration{
+	{
+		version:     2,
+		description: "move secrets to the credential store and complete the profiles with the top-level settings",
+		migrate:     migrateToV2,
+	},
+}
+
+// migrateConfig upgrades a configuration of an older version, in memory.
+// Returns the version read, and whether the configuration was migrated.
+func migrateConfig() (int, bool, error) {
+	version := cfg.Version
+	if version == 0 {
+		version = 1
+	}
+
+	switch {
+	case version > ConfigVersion:
+		return version, false, fmt.Errorf("%s is of version %d, newer than supported (%d): upgrade gateplane",
+			configFile, version, ConfigVersion)
+	case version == ConfigVersion:
+		return version, false, nil
+	}
+
+	for _, migration := range configMigrations {
+		if migration.version <= version {
+			continue
+		}
+		if err := migration.migrate(); err != nil {
+			return version, false, fmt.Errorf("failed to migrate the configuration to version %d (%s): %w",
+				migration.version, migration.description, err)
+		}
+	}
+	cfg.Version = ConfigVersion
+	return version, true, nil
+}
+
+// backupConfig copies the configuration file of a version before it is migrated, returning the path of the copy.
+// The copy is readable only by the user, as older versions kept secrets in the file.
+func backupConfig(version int) (string, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to back up config file: %w", err)
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", configFile, version)
+	if err := writeFileAtomic(backup, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to back up config file: %w", err)
+	}
+	return backup, nil
+}
+
+// migrateToV2 moves the secrets of the configuration file to the credential store,
+// and turns the overrides of older profiles into complete profiles
+func migrateToV2() error {
+	if err := migratePlaintextSecrets(); err != nil {
+		return err
+	}
+	migrateLegacyProfiles()
+	return nil
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+)
+
+// ProfileConfig contains the settings of a configuration profile (a context, as in kubectl).
+// The top-level settings of the configuration are the default profile.
+type ProfileConfig struct {
+	Vault    VaultConfig    `yaml:"vault"`
+	Service  ServiceConfig  `yaml:"service"`
+	Defaults DefaultsConfig `yaml:"defaults"`
+	Gates    []models.Gate  `yaml:"gates,omitempty"`
+
+	// Overrides of the top-level settings in older versions, moved to the settings above on load
+	VaultAddress string `mapstructure:"vault_address" yaml:"vault_address,omitempty"`
+	DefaultGate  string `mapstructure:"default_gate" yaml:"default_gate,omitempty"`
+	Namespace    string `yaml:"namespace,omitempty"`
+}
+
+// DefaultProfile names the top-level settings of the configuration
+const DefaultProfile = "default"
+
+// ProfileEnv selects a profile for a single command, like --profile
+const ProfileEnv = "GATEPLANE_PROFILE"
+
+// Profile names are used as configuration keys, which are case-insensitive and split on dots,
+// and in the keys of the stored Vault tokens
+var profileNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+var (
+	// The profile whose settings are in cfg, empty for the default profile
+	activeProfile string
+	// The top-level settings, while another profile is active
+	baseSettings ProfileConfig
+)
+
+// withoutSecrets returns the profile without the secrets kept in the credential store
+func (p ProfileConfig) withoutSecrets() ProfileConfig {
+	p.Vault.Token = ""
+	p.Service.JWT = ""
+	return p
+}
+
+// currentSettings returns the settings in use
+func currentSettings() ProfileConfig {
+	return ProfileConfig{
+		Vault:    cfg.Vault,
+		Service:  cfg.Service,
+		Defaults: cfg.Defaults,
+		Gates:    cfg.Gates,
+	}
+}
+
+// setSettings puts the settings of a profile in use
+func setSettings(profile ProfileConfig) {
+	cfg.Vault = profile.Vault
+	cfg.Service = profile.Service
+	cfg.Defaults = profile.Defaults
+	cfg.Gates = profile.Gates
+}
+
+// activateProfile puts the settings and secrets of a profile in use, with the overlay
+// of the environment, without saving. An empty name selects the default profile.
+func activateProfile(name string) error {
+	// Only the profile in use is overlaid
+	settings := clearOverlay()
+	if activeProfile != "" {
+		cfg.Profiles[activeProfile] = settings
+		settings = baseSettings
+		activeProfile = ""
+	}
+	setSettings(settings)
+
+	var err error
+	if name != "" && name != DefaultProfile {
+		if profile, ok := cfg.Profiles[name]; ok {
+			baseSettings = currentSettings()
+			setSettings(profile)
+			activeProfile = name
+		} else {
+			err = fmt.Errorf("profile %s not found", name)
+		}
+	}
+
+	applyOverlay()
+	resolveSecrets()
+	return err
+}
+
+// migrateLegacyProfiles turns the overrides of older profiles into complete profiles,
+// based on the top-level settings
+func migrateLegacyProfiles() {
+	for name, profile := range cfg.Profiles {
+		if profile.VaultAddress == "" && profile.DefaultGate == "" && profile.Namespace == "" {
+			continue
+		}
+
+		settings := currentSettings().withoutSecrets()
+		settings.Gates = slices.Clone(settings.Gates)
+		if profile.VaultAddress != "" {
+			settings.Vault.Address = profile.VaultAddress
+		}
+		if profile.DefaultGate != "" {
+			settings.Defaults.Gate = profile.DefaultGate
+		}
+		if profile.Namespace != "" {
+			settings.Vault.Namespace = profile.Namespace
+		}
+		cfg.Profiles[name] = settings
+	}
+}
+
+// ValidateProfileName checks that a name can be used for a new profile
+func ValidateProfileName(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("%s is the name of the top-level settings", DefaultProfile)
+	}
+	if !profileNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ActiveProfile returns the name of the profile in use
+func ActiveProfile() string {
+	if activeProfile == "" {
+		return DefaultProfile
+	}
+	return activeProfile
+}
+
+// ProfileNames returns the names of the profiles, starting with the default profile
+func ProfileNames() []string {
+	return append([]string{DefaultProfile}, slices.Sorted(maps.Keys(cfg.Profiles))...)
+}
+
+// GetProfile returns the settings of a profile, without its secrets
+func GetProfile(name string) (ProfileConfig, bool) {
+	switch {
+	case name == ActiveProfile():
+		return currentSettings().withoutSecrets(), true
+	case name == DefaultProfile:
+		return baseSettings.withoutSecrets(), true
+	}
+	profile, ok := cfg.Profiles[name]
+	return profile.withoutSecrets(), ok
+}
+
+// HasProfileCredentials reports whether tokens are stored for the profile
+func HasProfileCredentials(name string) bool {
+	if storedServiceJWT(name) != "" {
+		return true
+	}
+	for key := range creds.VaultTokens {
+		if strings.HasPrefix(key, TokenKey(name, "")) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateProfile adds a profile and saves the configuration
+func CreateProfile(name string, profile ProfileConfig) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if _, exists := cfg.Profiles[name]; exists {
+		return fmt.Errorf("profile %s already exists", name)
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]ProfileConfig{}
+	}
+	cfg.Profiles[name] = profile.withoutSecrets()
+	return SaveConfig()
+}
+
+// UseProfile switches to the specified configuration profile and saves the changes
+func UseProfile(profileName string) error {
+	if err := activateProfile(profileName); err != nil {
+		return err
+	}
+
+	cfg.CurrentProfile = activeProfile
+	return SaveConfig()
+}
+
+// ApplyProfile puts a profile in use for the current command only
+func ApplyProfile(profileName string) error {
+	return activateProfile(profileName)
+}
+
+// DeleteProfile removes a profile and its stored tokens, and saves the configuration.
+// The default profile is used instead, if the profile was in use.
+func DeleteProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be deleted", DefaultProfile)
+	}
+	if _, exists := cfg.Profiles[name]; !exists {
+		return fmt.Errorf("profile %s not found", name)
+	}
+	if credsLocked {
+		return fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", PassphraseEnv)
+	}
+
+	if activeProfile == name {
+		if err := activateProfile(""); err != nil {
+			return err
+		}
+	}
+	if cfg.CurrentProfile == name {
+		cfg.CurrentProfile = ""
+	}
+	delete(cfg.Profiles, name)
+	renameProfileCredentials(name, "")
+
+	return SaveConfig()
+}
+
+// RenameProfile renames a profile along with its stored tokens, and saves the configuration
+func RenameProfile(name, newName string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be renamed", DefaultProfile)
+	}
+	if _, exists := cfg.Profiles[name]; !exists {
+		return fmt.Errorf("profile %s not found", name)
+	}
+	if err := ValidateProfileName(newName); err != nil {
+		return err
+	}
+	if _, exists := cfg.Profiles[newName]; exists {
+		return fmt.Errorf("profile %s already exists", newName)
+	}
+	if credsLocked {
+		return fmt.Errorf("the credential store is encrypted and locked, set %s to unlock it", PassphraseEnv)
+	}
+
+	wasActive := activeProfile == name
+	if wasActive {
+		if err := activateProfile(""); err != nil {
+			return err
+		}
+	}
+
+	cfg.Profiles[newName] = cfg.Profiles[name]
+	delete(cfg.Profiles, name)
+	renameProfileCredentials(name, newName)
+	if cfg.CurrentProfile == name {
+		cfg.CurrentProfile = newName
+	}
+
+	if wasActive {
+		if err := activateProfile(newName); err != nil {
+			return err
+		}
+	}
+	return SaveConfig()
+}
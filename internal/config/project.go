@@ -0,0 +1,142 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigName is the name of the project configuration file,
+// found in the working directory or the closest of its parents
+const ProjectConfigName = ".gateplane.yaml"
+
+// ProjectConfig contains the settings a project (repository) declares for the commands run in it,
+// layered over the user configuration and never saved to it
+type ProjectConfig struct {
+	Vault    ProjectVaultConfig    `yaml:"vault,omitempty"`
+	Defaults ProjectDefaultsConfig `yaml:"defaults,omitempty"`
+	Gates    []models.Gate         `yaml:"gates,omitempty"`
+}
+
+// ProjectVaultConfig contains the Vault settings of a project
+type ProjectVaultConfig struct {
+	Address   string `yaml:"address,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// ProjectDefaultsConfig contains the defaults of a project
+type ProjectDefaultsConfig struct {
+	Gate string `yaml:"gate,omitempty"`
+}
+
+var (
+	// The project configuration file in use, empty if none is found
+	projectFile string
+	project     ProjectConfig
+)
+
+// loadProjectConfig finds and reads the project configuration of the working directory
+func loadProjectConfig() error {
+	projectFile, project = "", ProjectConfig{}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	path, err := findProjectConfig(dir)
+	if path == "" || err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read project config: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&project); err != nil && !errors.Is(err, io.EOF) {
+		project = ProjectConfig{}
+		return fmt.Errorf("failed to parse project config %s: %w", path, err)
+	}
+	for i, gate := range project.Gates {
+		if gate.Path == "" {
+			project = ProjectConfig{}
+			return fmt.Errorf("failed to parse project config %s: gate %d has no path", path, i+1)
+		}
+		if gate.Type == "" {
+			project.Gates[i].Type = models.PolicyGate
+		}
+	}
+
+	projectFile = path
+	return nil
+}
+
+// findProjectConfig returns the path of the project configuration file
+// in a directory or the closest of its parents, or an empty path if there is none
+func findProjectConfig(dir string) (string, error) {
+	for {
+		path := filepath.Join(dir, ProjectConfigName)
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && !info.IsDir():
+			return path, nil
+		case err != nil && !os.IsNotExist(err):
+			return "", fmt.Errorf("failed to read project config: %w", err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// projectSettings returns the settings of the project as those of a profile
+func projectSettings() ProfileConfig {
+	var settings ProfileConfig
+	settings.Vault.Address = project.Vault.Address
+	settings.Vault.Namespace = project.Vault.Namespace
+	settings.Defaults.Gate = project.Defaults.Gate
+	return settings
+}
+
+// ProjectConfigFile returns the path of the project configuration file in use, if any
+func ProjectConfigFile() string {
+	return projectFile
+}
+
+// Gates returns the gates configured for the profile in use: those of the project
+// configuration, taking priority, followed by those of the user configuration
+func Gates() []models.Gate {
+	gates := slices.Clone(project.Gates)
+	for _, gate := range cfg.Gates {
+		if gate.Alias == "" || !isProjectGate(gate.Alias) {
+			gates = append(gates, gate)
+		}
+	}
+	return gates
+}
+
+// isProjectGate reports whether an alias is declared by the project configuration
+func isProjectGate(alias string) bool {
+	return slices.ContainsFunc(project.Gates, func(gate models.Gate) bool { return gate.Alias == alias })
+}
@@ -0,0 +1,486 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+)
+
+// Setting describes a configuration key of 'config get/set/unset'.
+// Keys are the YAML paths of the settings (e.g. 'vault.address').
+type Setting struct {
+	Key         string
+	Type        string   // "string" or "bool"
+	Values      []string // The allowed values, if restricted
+	Description string
+	// Global settings are not part of profiles
+	Global bool
+
+	// Field index in ProfileConfig, or in Config for global settings
+	index []int
+	// Validates a value, returning its normalized form
+	normalize func(string) (string, error)
+}
+
+// settingDoc documents and validates a setting found in the configuration structs
+type settingDoc struct {
+	description string
+	values      []string
+	normalize   func(string) (string, error)
+}
+
+// Key prefixes of the settings of another profile and of gate aliases
+const (
+	ProfileKeyPrefix = "profiles."
+	AliasKeyPrefix   = "gates."
+)
+
+var settingDocs = map[string]settingDoc{
+	"vault.address":         {description: "Vault server address", normalize: normalizeAddress},
+	"vault.namespace":       {description: "Vault namespace"},
+	"vault.token_helper":    {description: "External Vault token helper", normalize: normalizeExecutable},
+	"vault.ca_cert":         {description: "CA certificate file to verify the Vault server", normalize: normalizeFile},
+	"vault.ca_path":         {description: "Directory of CA certificates to verify the Vault server", normalize: normalizeDir},
+	"vault.client_cert":     {description: "Client certificate file for TLS authentication", normalize: normalizeFile},
+	"vault.client_key":      {description: "Client key file for TLS authentication", normalize: normalizeFile},
+	"vault.tls_server_name": {description: "Server name (SNI) of the Vault server"},
+	"vault.tls_skip_verify": {description: "Do not verify the Vault server certificate (insecure)"},
+	"service.client_id":     {description: "GatePlane Services client ID"},
+	"defaults.gate":         {description: "Gate used when none is given"},
+	"defaults.output_format": {
+		description: "Default output format",
+		values:      []string{"table", "json", "yaml"},
+	},
+	"catalog": {description: "Gate catalog file, used when gates cannot be discovered", normalize: normalizeCatalog},
+}
+
+// managedSettings are not set directly, but through the given command
+var managedSettings = map[string]string{
+	"vault.token":     "gateplane auth login",
+	"service.jwt":     "gateplane auth service login",
+	"current_profile": "gateplane config profile use",
+	"profiles":        "gateplane config profile",
+	"gates":           "gateplane config set gates.<alias> <path>",
+}
+
+// Settings returns the settings of the configuration, profile settings first
+func Settings() []Setting {
+	var settings []Setting
+
+	profileType := reflect.TypeOf(ProfileConfig{})
+	for i := range profileType.NumField() {
+		section := profileType.Field(i)
+		if section.Type.Kind() != reflect.Struct {
+			continue
+		}
+		for j := range section.Type.NumField() {
+			field := section.Type.Field(j)
+			key := yamlName(section) + "." + yamlName(field)
+			if setting, ok := newSetting(key, field.Type, []int{i, j}); ok {
+				settings = append(settings, setting)
+			}
+		}
+	}
+
+	configType := reflect.TypeOf(Config{})
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+		if _, inProfile := profileType.FieldByName(field.Name); inProfile {
+			continue
+		}
+		if setting, ok := newSetting(yamlName(field), field.Type, []int{i}); ok {
+			setting.Global = true
+			settings = append(settings, setting)
+		}
+	}
+
+	return settings
+}
+
+func newSetting(key string, fieldType reflect.Type, index []int) (Setting, bool) {
+	if _, managed := managedSettings[key]; managed {
+		return Setting{}, false
+	}
+	if fieldType.Kind() != reflect.String && fieldType.Kind() != reflect.Bool {
+		return Setting{}, false
+	}
+
+	doc := settingDocs[key]
+	return Setting{
+		Key:         key,
+		Type:        fieldType.Kind().String(),
+		Values:      doc.values,
+		Description: doc.description,
+		index:       index,
+		normalize:   doc.normalize,
+	}, true
+}
+
+// yamlName returns the key of a struct field in the configuration file
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// settingRef is a parsed key: a setting or a gate alias, of a profile
+type settingRef struct {
+	profile string
+	setting Setting
+	alias   string
+}
+
+// parseSettingKey resolves a key to a setting of the active profile,
+// or of another profile with the 'profiles.<name>.' prefix
+func parseSettingKey(key string) (settingRef, error) {
+	ref := settingRef{profile: ActiveProfile()}
+
+	if rest, ok := strings.CutPrefix(key, ProfileKeyPrefix); ok {
+		name, subKey, found := strings.Cut(rest, ".")
+		if !found || subKey == "" {
+			return ref, fmt.Errorf("incomplete key %q: use %s<profile>.<key>", key, ProfileKeyPrefix)
+		}
+		if _, exists := GetProfile(name); !exists {
+			return ref, fmt.Errorf("profile %s not found", name)
+		}
+		ref.profile = name
+		key = subKey
+	}
+
+	if alias, ok := strings.CutPrefix(key, AliasKeyPrefix); ok && alias != "" {
+		ref.alias = alias
+		return ref, nil
+	}
+
+	key = strings.ReplaceAll(strings.ToLower(key), "-", "_")
+	if command, managed := managedSettings[key]; managed {
+		return ref, fmt.Errorf("%s cannot be set directly, use '%s'", key, command)
+	}
+
+	settings := Settings()
+	for _, setting := range settings {
+		if setting.Key != key {
+			continue
+		}
+		if setting.Global && ref.profile != ActiveProfile() {
+			return ref, fmt.Errorf("%s is not a profile setting", key)
+		}
+		ref.setting = setting
+		return ref, nil
+	}
+
+	return ref, unknownSettingError(key, settings)
+}
+
+// unknownSettingError reports an unknown key, suggesting the closest known ones
+func unknownSettingError(key string, settings []Setting) error {
+	var suggestions []string
+	for _, setting := range settings {
+		section, _, _ := strings.Cut(setting.Key, ".")
+		if levenshtein(key, setting.Key) <= 3 || (section != setting.Key && key == section) ||
+			strings.HasSuffix(setting.Key, "."+key) {
+			suggestions = append(suggestions, setting.Key)
+		}
+	}
+
+	if len(suggestions) > 0 {
+		return fmt.Errorf("unknown configuration key %q. Did you mean: %s?", key, strings.Join(suggestions, ", "))
+	}
+	return fmt.Errorf("unknown configuration key %q. Run 'gateplane config set --help' for the available keys", key)
+}
+
+// withProfileSettings runs fn on the settings of a profile, keeping its changes in memory
+func withProfileSettings(name string, fn func(*ProfileConfig) error) error {
+	switch {
+	case name == ActiveProfile():
+		settings := currentSettings()
+		if err := fn(&settings); err != nil {
+			return err
+		}
+		setSettings(settings)
+		// The address and token helper select the token
+		resolveSecrets()
+		return nil
+	case name == DefaultProfile:
+		return fn(&baseSettings)
+	}
+
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %s not found", name)
+	}
+	if err := fn(&profile); err != nil {
+		return err
+	}
+	cfg.Profiles[name] = profile
+	return nil
+}
+
+// field returns the field of a setting in the profile (or the configuration, for global settings)
+func (ref settingRef) field(profile *ProfileConfig) reflect.Value {
+	if ref.setting.Global {
+		return reflect.ValueOf(cfg).Elem().FieldByIndex(ref.setting.index)
+	}
+	return reflect.ValueOf(profile).Elem().FieldByIndex(ref.setting.index)
+}
+
+// value returns the value of a setting in the profile, as a string
+func (ref settingRef) value(profile *ProfileConfig) string {
+	return fmt.Sprint(ref.field(profile).Interface())
+}
+
+// set parses and sets the value of a setting in the profile
+func (ref settingRef) set(profile *ProfileConfig, value string) error {
+	parsed := reflect.ValueOf(value)
+	if ref.setting.Type == reflect.Bool.String() {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s. Must be true or false", value, ref.setting.Key)
+		}
+		parsed = reflect.ValueOf(b)
+	}
+
+	field := ref.field(profile)
+	field.Set(parsed.Convert(field.Type()))
+	return nil
+}
+
+// markExplicit keeps a setting of the active profile set on the command line,
+// even if equal to the value of the environment
+func (ref settingRef) markExplicit() {
+	if ref.profile == ActiveProfile() {
+		delete(overlay, ref.setting.Key)
+	}
+}
+
+// GetSetting returns the value of a configuration key
+func GetSetting(key string) (string, error) {
+	ref, err := parseSettingKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	profile, _ := GetProfile(ref.profile)
+	if ref.alias != "" {
+		gates := profile.Gates
+		if ref.profile == ActiveProfile() {
+			gates = Gates()
+		}
+		for _, gate := range gates {
+			if gate.Alias == ref.alias {
+				return gate.Path, nil
+			}
+		}
+		return "", fmt.Errorf("gate with alias %s not found", ref.alias)
+	}
+	return ref.value(&profile), nil
+}
+
+// SetSetting validates and sets the value of a configuration key, and saves the configuration
+func SetSetting(key, value string) error {
+	ref, err := parseSettingKey(key)
+	if err != nil {
+		return err
+	}
+
+	if ref.alias != "" {
+		return setAlias(ref, value)
+	}
+
+	setting := ref.setting
+	if len(setting.Values) > 0 && !slices.Contains(setting.Values, value) {
+		return fmt.Errorf("invalid value %q for %s. Must be one of: %s", value, setting.Key, strings.Join(setting.Values, ", "))
+	}
+	if setting.normalize != nil {
+		if value, err = setting.normalize(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", setting.Key, err)
+		}
+	}
+
+	err = withProfileSettings(ref.profile, func(profile *ProfileConfig) error {
+		return ref.set(profile, value)
+	})
+	if err != nil {
+		return err
+	}
+	ref.markExplicit()
+	return SaveConfig()
+}
+
+// UnsetSetting resets a configuration key to its default, or removes a gate alias, and saves the configuration
+func UnsetSetting(key string) error {
+	ref, err := parseSettingKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = withProfileSettings(ref.profile, func(profile *ProfileConfig) error {
+		if ref.alias != "" {
+			i := slices.IndexFunc(profile.Gates, func(gate models.Gate) bool { return gate.Alias == ref.alias })
+			if i < 0 {
+				return fmt.Errorf("gate with alias %s not found", ref.alias)
+			}
+			profile.Gates = slices.Delete(slices.Clone(profile.Gates), i, i+1)
+			return nil
+		}
+		field := ref.field(profile)
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if ref.alias == "" {
+		ref.markExplicit()
+	}
+	return SaveConfig()
+}
+
+// setAlias points a gate alias to a gate path, adding it (as a policy gate) if needed
+func setAlias(ref settingRef, path string) error {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return fmt.Errorf("the gate path cannot be empty")
+	}
+
+	err := withProfileSettings(ref.profile, func(profile *ProfileConfig) error {
+		gates := slices.Clone(profile.Gates)
+		defer func() { profile.Gates = gates }()
+
+		if i := slices.IndexFunc(gates, func(gate models.Gate) bool { return gate.Alias == ref.alias }); i >= 0 {
+			gates[i].Path = path
+			return nil
+		}
+		if i := slices.IndexFunc(gates, func(gate models.Gate) bool { return gate.Path == path }); i >= 0 {
+			gates[i].Alias = ref.alias
+			return nil
+		}
+		gates = append(gates, models.Gate{Path: path, Alias: ref.alias, Type: models.PolicyGate})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return SaveConfig()
+}
+
+// AllSettings returns the values of the settings of the active profile, the global settings
+// and the gate aliases, by key
+func AllSettings() map[string]string {
+	values := map[string]string{}
+	for _, setting := range Settings() {
+		if value, err := GetSetting(setting.Key); err == nil {
+			values[setting.Key] = value
+		}
+	}
+	for _, gate := range Gates() {
+		if gate.Alias != "" {
+			values[AliasKeyPrefix+gate.Alias] = gate.Path
+		}
+	}
+	return values
+}
+
+func normalizeAddress(value string) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%q is not an http(s) URL", value)
+	}
+	return strings.TrimSuffix(value, "/"), nil
+}
+
+func normalizeExecutable(value string) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+	resolved, err := exec.LookPath(value)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(resolved)
+}
+
+func normalizeFile(value string) (string, error) {
+	return normalizePath(value, false)
+}
+
+func normalizeDir(value string) (string, error) {
+	return normalizePath(value, true)
+}
+
+// normalizePath returns the absolute path of an existing file or directory
+func normalizePath(value string, dir bool) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+	path, err := filepath.Abs(value)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() != dir {
+		if dir {
+			return "", fmt.Errorf("%s is not a directory", path)
+		}
+		return "", fmt.Errorf("%s is a directory", path)
+	}
+	return path, nil
+}
+
+// normalizeCatalog returns the absolute path of a gate catalog, which may not exist yet
+func normalizeCatalog(value string) (string, error) {
+	if value == "" || strings.HasPrefix(value, "~") {
+		return value, nil
+	}
+	return filepath.Abs(value)
+}
+
+// levenshtein returns the edit distance of two keys
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Severities of the issues found in the configuration
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// ValidationIssue is a problem found in the configuration file
+type ValidationIssue struct {
+	Line     int    `json:"line" yaml:"line"`
+	Key      string `json:"key,omitempty" yaml:"key,omitempty"`
+	Severity string `json:"severity" yaml:"severity"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+// GateChecker checks that the default gate of a profile can be reached,
+// returning nil if it cannot be checked
+type GateChecker func(profile, gate string) error
+
+// Keys of the profiles of older versions, migrated on load, and the keys replacing them
+var legacyProfileKeys = map[string]string{
+	"vault_address": "vault.address",
+	"default_gate":  "defaults.gate",
+	"namespace":     "vault.namespace",
+}
+
+var yamlLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// validator collects the issues of a configuration file
+type validator struct {
+	issues    []ValidationIssue
+	checkGate GateChecker
+}
+
+// ValidateConfig checks the configuration file for unknown keys, invalid values, duplicate gate aliases
+// and default gates that are not found, or cannot be reached according to checkGate (if not nil).
+// Issues are sorted by line.
+func ValidateConfig(checkGate GateChecker) ([]ValidationIssue, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		issue := ValidationIssue{Severity: SeverityError, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if match := yamlLineRegexp.FindStringSubmatch(err.Error()); match != nil {
+			issue.Line, _ = strconv.Atoi(match[1])
+		}
+		return []ValidationIssue{issue}, nil
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	v := &validator{checkGate: checkGate}
+	root := doc.Content[0]
+	v.walk(root, reflect.TypeOf(Config{}), "")
+	if root.Kind == yaml.MappingNode {
+		v.checkVersion(root)
+		v.checkProfiles(root)
+	}
+
+	slices.SortStableFunc(v.issues, func(a, b ValidationIssue) int { return cmp.Compare(a.Line, b.Line) })
+	return v.issues, nil
+}
+
+func (v *validator) report(node *yaml.Node, severity, key, format string, args ...any) {
+	v.issues = append(v.issues, ValidationIssue{
+		Line:     node.Line,
+		Key:      key,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// walk checks a node against the type of the configuration it decodes to
+func (v *validator) walk(node *yaml.Node, t reflect.Type, key string) {
+	if node.Tag == "!!null" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.report(node, SeverityError, key, "must be a mapping")
+			return
+		}
+		fields := map[string]reflect.StructField{}
+		for i := range t.NumField() {
+			fields[yamlName(t.Field(i))] = t.Field(i)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, value := node.Content[i], node.Content[i+1]
+			subKey := joinKey(key, name.Value)
+			field, known := fields[name.Value]
+			if !known {
+				v.unknownKey(name, subKey, slices.Sorted(maps.Keys(fields)))
+				continue
+			}
+			if replacement, legacy := legacyProfileKeys[name.Value]; legacy && t == reflect.TypeOf(ProfileConfig{}) {
+				v.report(name, SeverityWarning, subKey, "deprecated, replaced by %s", replacement)
+			}
+			if (name.Value == "token" || name.Value == "jwt") && value.Value != "" {
+				v.report(value, SeverityWarning, subKey, "secrets are kept in the credential store, remove it and log in again")
+			}
+			v.walk(value, field.Type, subKey)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.report(node, SeverityError, key, "must be a mapping")
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.walk(node.Content[i+1], t.Elem(), joinKey(key, node.Content[i].Value))
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.report(node, SeverityError, key, "must be a list")
+			return
+		}
+		for i, item := range node.Content {
+			v.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", key, i))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.report(node, SeverityError, key, "must be true or false")
+		}
+	case reflect.Int:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.report(node, SeverityError, key, "must be a number")
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			v.report(node, SeverityError, key, "must be a string")
+			return
+		}
+		v.checkValue(node, key)
+	}
+}
+
+// checkValue checks the value of a string setting
+func (v *validator) checkValue(node *yaml.Node, key string) {
+	// Settings of a profile are checked like the top-level ones
+	setting := key
+	if rest, ok := strings.CutPrefix(key, ProfileKeyPrefix); ok {
+		_, setting, _ = strings.Cut(rest, ".")
+	}
+
+	if values := settingDocs[setting].values; len(values) > 0 && node.Value != "" && !slices.Contains(values, node.Value) {
+		v.report(node, SeverityError, key, "invalid value %q, must be one of: %s", node.Value, strings.Join(values, ", "))
+	}
+
+	switch {
+	case strings.HasPrefix(setting, "gates[") && strings.HasSuffix(setting, "].type") && node.Value != "":
+		if _, ok := models.LookupGateType(node.Value); !ok {
+			v.report(node, SeverityError, key, "unknown gate type %q, must be one of: %s",
+				node.Value, strings.Join(models.GateTypeNames(), ", "))
+		}
+	case setting == "catalog" && node.Value != "":
+		if _, err := LoadGateCatalog(node.Value); err != nil {
+			v.report(node, SeverityWarning, key, "%v", err)
+		}
+	}
+}
+
+func (v *validator) unknownKey(node *yaml.Node, key string, known []string) {
+	for _, name := range known {
+		if levenshtein(node.Value, name) <= 2 {
+			v.report(node, SeverityError, key, "unknown key %q, did you mean %q?", node.Value, name)
+			return
+		}
+	}
+	v.report(node, SeverityError, key, "unknown key %q", node.Value)
+}
+
+func (v *validator) checkVersion(root *yaml.Node) {
+	node := mappingValue(root, "version")
+	if node == nil {
+		v.report(root, SeverityWarning, "version", "no version, the file is migrated to version %d when next loaded", ConfigVersion)
+		return
+	}
+	if version, err := strconv.Atoi(node.Value); err == nil && version > ConfigVersion {
+		v.report(node, SeverityError, "version", "version %d is newer than supported (%d), upgrade gateplane", version, ConfigVersion)
+	}
+}
+
+// checkProfiles checks the current profile, profile names, and the gates of each profile
+func (v *validator) checkProfiles(root *yaml.Node) {
+	v.checkGates(root, DefaultProfile, "")
+
+	profiles := mappingValue(root, "profiles")
+	if profiles != nil && profiles.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(profiles.Content); i += 2 {
+			name, profile := profiles.Content[i], profiles.Content[i+1]
+			prefix := ProfileKeyPrefix + name.Value + "."
+			if err := ValidateProfileName(name.Value); err != nil {
+				v.report(name, SeverityError, strings.TrimSuffix(prefix, "."), "%v", err)
+			}
+			v.checkGates(profile, name.Value, prefix)
+		}
+	}
+
+	if current := mappingValue(root, "current_profile"); current != nil && current.Value != "" &&
+		current.Value != DefaultProfile && mappingValue(profiles, current.Value) == nil {
+		v.report(current, SeverityError, "current_profile", "profile %s not found", current.Value)
+	}
+}
+
+// checkGates checks the gate aliases and the default gate of a profile
+func (v *validator) checkGates(settings *yaml.Node, profile, prefix string) {
+	aliases := map[string]*yaml.Node{}
+	paths := map[string]*yaml.Node{}
+
+	if gates := mappingValue(settings, "gates"); gates != nil && gates.Kind == yaml.SequenceNode {
+		for i, gate := range gates.Content {
+			key := fmt.Sprintf("%sgates[%d]", prefix, i)
+			if path := mappingValue(gate, "path"); path != nil && path.Value != "" {
+				if first, ok := paths[path.Value]; ok {
+					v.report(path, SeverityWarning, key+".path", "duplicate gate %s, first listed on line %d", path.Value, first.Line)
+				} else {
+					paths[path.Value] = path
+				}
+			} else {
+				v.report(gate, SeverityError, key, "gate without a path")
+			}
+
+			if alias := mappingValue(gate, "alias"); alias != nil && alias.Value != "" {
+				if first, ok := aliases[alias.Value]; ok {
+					v.report(alias, SeverityError, key+".alias", "duplicate alias %s, first defined on line %d", alias.Value, first.Line)
+				} else {
+					aliases[alias.Value] = alias
+				}
+			}
+		}
+	}
+
+	node := mappingValue(mappingValue(settings, "defaults"), "gate")
+	if node == nil || node.Value == "" {
+		return
+	}
+	key := prefix + "defaults.gate"
+
+	// Resolved as by ResolveGatePath
+	gate := node.Value
+	if alias, isAlias := strings.CutPrefix(gate, "@"); isAlias || aliases[gate] != nil {
+		if aliases[alias] == nil {
+			v.report(node, SeverityError, key, "default gate %s: alias %s not found", gate, alias)
+			return
+		}
+		gate = gatePathOfAlias(settings, alias)
+	}
+
+	if v.checkGate != nil {
+		if err := v.checkGate(profile, gate); err != nil {
+			v.report(node, SeverityWarning, key, "default gate %s cannot be reached: %v", gate, err)
+		}
+	}
+}
+
+// gatePathOfAlias returns the path of the gate with an alias, in the gates of a profile
+func gatePathOfAlias(settings *yaml.Node, alias string) string {
+	gates := mappingValue(settings, "gates")
+	if gates == nil {
+		return ""
+	}
+	for _, gate := range gates.Content {
+		if a := mappingValue(gate, "alias"); a != nil && a.Value == alias {
+			return gatePath(gate)
+		}
+	}
+	return ""
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
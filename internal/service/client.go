@@ -48,7 +48,11 @@ type NotificationType string
 const (
 	Request NotificationType = "request"
 	Approve NotificationType = "approval"
+	Reject  NotificationType = "rejection"
 	Claim   NotificationType = "claim"
+	Cancel  NotificationType = "cancellation"
+	Revoke  NotificationType = "revocation"
+	Release NotificationType = "release"
 	Test    NotificationType = "test"
 )
 
@@ -0,0 +1,123 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package vault
+
+import (
+	"strings"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+	vault "github.com/hashicorp/vault/api"
+)
+
+// mountMatch is a mount a policy path applies to
+type mountMatch struct {
+	mountPath string
+	// The policy path relative to the mount ('*' if a glob covers the whole mount)
+	relativePath string
+}
+
+// matchMounts finds the mounts a policy path applies to.
+// A path falls under the most specific mount it is nested in, with '+' matching any segment.
+// A path ending in a glob (e.g. 'sec*' or '*') also covers every mount its prefix leads to.
+func matchMounts(policyPath string, mounts map[string]*vault.MountOutput) []mountMatch {
+	var (
+		matches  []mountMatch
+		best     mountMatch
+		bestSegs int
+	)
+
+	policySegs := strings.Split(policyPath, "/")
+	globPrefix, isGlob := strings.CutSuffix(policyPath, "*")
+
+	for mountPath := range mounts {
+		mountSegs := strings.Split(strings.TrimSuffix(mountPath, "/"), "/")
+
+		if relative, ok := matchMountSegments(policySegs, mountSegs); ok {
+			if len(mountSegs) > bestSegs {
+				best = mountMatch{mountPath: mountPath, relativePath: relative}
+				bestSegs = len(mountSegs)
+			}
+			continue
+		}
+
+		if isGlob && len(globPrefix) < len(mountPath) && strings.HasPrefix(mountPath, globPrefix) {
+			matches = append(matches, mountMatch{mountPath: mountPath, relativePath: "*"})
+		}
+	}
+
+	if bestSegs > 0 {
+		matches = append(matches, best)
+	}
+	return matches
+}
+
+// matchMountSegments reports whether the policy path is nested in the mount,
+// returning the remainder of the path
+func matchMountSegments(policySegs, mountSegs []string) (string, bool) {
+	if len(policySegs) < len(mountSegs) {
+		return "", false
+	}
+	for i, segment := range mountSegs {
+		if policySegs[i] != segment && policySegs[i] != "+" {
+			return "", false
+		}
+	}
+	return strings.Join(policySegs[len(mountSegs):], "/"), true
+}
+
+// isKVMount reports whether the mount is a KV secrets engine
+func isKVMount(mount *vault.MountOutput) bool {
+	return mount.Type == "kv" || mount.Type == "generic"
+}
+
+// kvVersion returns the KV version of a KV mount, which defaults to 1
+func kvVersion(mount *vault.MountOutput) string {
+	if version := mount.Options["version"]; version != "" {
+		return version
+	}
+	return "1"
+}
+
+func newAccessBlock(mount *vault.MountOutput) models.AccessBlock {
+	block := models.AccessBlock{
+		MountType:   mount.Type,
+		Version:     mount.RunningVersion,
+		Description: mount.Description,
+	}
+	if isKVMount(mount) {
+		block.Version = kvVersion(mount)
+	}
+	return block
+}
+
+// newMountPath places a policy path under its mount.
+// On KV v2 mounts, the API endpoint is split from the logical secret path
+// (e.g. 'secret/metadata/app/*' is the 'metadata' of 'app/*').
+func newMountPath(path models.PathBlock, mount *vault.MountOutput, relativePath string) models.MountPath {
+	mountPath := models.MountPath{PathBlock: path}
+	if !isKVMount(mount) || relativePath == "" {
+		return mountPath
+	}
+
+	if kvVersion(mount) != "2" {
+		mountPath.LogicalPath = relativePath
+		return mountPath
+	}
+
+	endpoint, logical, _ := strings.Cut(relativePath, "/")
+	if endpoint == "*" {
+		// A glob over the mount covers every endpoint and secret
+		logical = "*"
+	}
+	mountPath.Endpoint = endpoint
+	mountPath.LogicalPath = logical
+	return mountPath
+}
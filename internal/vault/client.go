@@ -12,17 +12,17 @@ package vault
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
-	"log"
-
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 
 	"github.com/gateplane-io/client-cli/pkg/errors"
 	"github.com/gateplane-io/client-cli/pkg/models"
-	"github.com/hashicorp/hcl/v2/hclsimple"
 	vault "github.com/hashicorp/vault/api"
 
 	"github.com/gateplane-io/vault-plugins/pkg/responses"
@@ -32,6 +32,14 @@ import (
 type Client struct {
 	client *vault.Client
 	config *Config
+
+	// Mounts are listed once per Client and shared by all gate lookups
+	mountsMu  sync.Mutex
+	mounts    map[string]*vault.MountOutput
+	mountsErr error
+
+	authMounts    map[string]*vault.MountOutput
+	authMountsErr error
 }
 
 // Config holds the configuration for connecting to Vault
@@ -39,6 +47,17 @@ type Config struct {
 	Address   string
 	Token     string
 	Namespace string
+	// Path of an external Vault token helper, asked for the token instead of ~/.vault-token
+	TokenHelper string
+	// TLS settings, overriding VAULT_CACERT, VAULT_CAPATH, VAULT_CLIENT_CERT, VAULT_CLIENT_KEY,
+	// VAULT_TLS_SERVER_NAME and VAULT_SKIP_VERIFY when set
+	TLS *vault.TLSConfig
+	// Gates known without asking Vault (configured aliases and the gate catalog),
+	// used when the mounts cannot be listed
+	KnownGates []models.Gate
+	// Use Token only, never VAULT_TOKEN, the token helper or ~/.vault-token,
+	// for an address the user did not choose (e.g. of a project configuration)
+	StoredTokenOnly bool
 }
 
 // NewClient creates a new Vault client with the provided configuration
@@ -60,21 +79,37 @@ func NewClient(config *Config) (*Client, error) {
 		}
 	}
 
+	if config.TLS != nil {
+		if err := vaultConfig.ConfigureTLS(config.TLS); err != nil {
+			return nil, fmt.Errorf("failed to configure vault TLS: %w", err)
+		}
+	}
+
 	client, err := vault.NewClient(vaultConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vault client: %w", err)
 	}
 
-	// Read the vault token from conf / env / vault login file
-	home, err := homedir.Dir()
-	vaultTokenFile := fmt.Sprintf("%s/.vault-token", home)
-	data, err := os.ReadFile(vaultTokenFile)
-	if config.Token != "" {
+	// Read the vault token from conf / env / token helper or vault login file.
+	// The client reads VAULT_TOKEN by itself, hence set even if empty.
+	if config.Token != "" || config.StoredTokenOnly {
 		client.SetToken(config.Token)
 	} else if token := os.Getenv("VAULT_TOKEN"); token != "" {
 		client.SetToken(token)
-	} else if err == nil && string(data) != "" {
-		client.SetToken(string(data))
+	} else if config.TokenHelper != "" {
+		helper := &TokenHelper{Path: config.TokenHelper}
+		token, err := helper.Get(vaultConfig.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vault token: %w", err)
+		}
+		client.SetToken(token)
+	} else {
+		home, err := homedir.Dir()
+		vaultTokenFile := fmt.Sprintf("%s/.vault-token", home)
+		data, err := os.ReadFile(vaultTokenFile)
+		if err == nil && string(data) != "" {
+			client.SetToken(string(data))
+		}
 	}
 
 	if config.Namespace != "" {
@@ -93,36 +128,159 @@ func (c *Client) VaultClient() *vault.Client {
 	return c.client
 }
 
-func (c *Client) DiscoverGates() ([]*models.Gate, error) {
-	auths, err := c.client.Sys().ListMounts()
+// listMounts returns the secret mounts of Vault, listing them only on the first call
+func (c *Client) listMounts() (map[string]*vault.MountOutput, error) {
+	c.mountsMu.Lock()
+	defer c.mountsMu.Unlock()
+
+	if c.mounts == nil && c.mountsErr == nil {
+		c.mounts, c.mountsErr = c.client.Sys().ListMounts()
+	}
+	return c.mounts, c.mountsErr
+}
+
+// listAuthMounts returns the auth mounts of Vault, listing them only on the first call
+func (c *Client) listAuthMounts() (map[string]*vault.MountOutput, error) {
+	c.mountsMu.Lock()
+	defer c.mountsMu.Unlock()
+
+	if c.authMounts == nil && c.authMountsErr == nil {
+		c.authMounts, c.authMountsErr = c.client.Sys().ListAuth()
+	}
+	return c.authMounts, c.authMountsErr
+}
+
+// lookupMount returns the mount of a gate path, using the cached mount list when available
+func (c *Client) lookupMount(path string) (*vault.MountOutput, error) {
+	mounts, err := c.listMounts()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list auth methods: %w", err)
+		// Listing may be denied while reading a single mount is allowed
+		if mount, err := c.client.Sys().GetMount(path); err == nil {
+			return mount, nil
+		}
+		resp, err := c.client.Logical().Read("sys/internal/ui/mounts/" + strings.TrimSuffix(path, "/"))
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil || resp.Data == nil {
+			return nil, fmt.Errorf("mount %s not found", path)
+		}
+		return decodeMount(resp.Data)
 	}
 
+	mount, ok := mounts[strings.TrimSuffix(path, "/")+"/"]
+	if !ok {
+		return nil, fmt.Errorf("mount %s not found", path)
+	}
+	return mount, nil
+}
+
+// DiscoverGates finds the GatePlane gates, trying each source in turn:
+// 'sys/mounts' (requires read permission), 'sys/internal/ui/mounts'
+// (mounts the caller has any capability on, as used by the Vault UI)
+// and finally the gates known from the configuration and the gate catalog.
+func (c *Client) DiscoverGates() ([]*models.Gate, error) {
+	var errs []error
+
+	mounts, err := c.listMounts()
+	if err == nil {
+		if gates := gatesFromMounts(mounts, models.SourceMounts); len(gates) > 0 {
+			return gates, nil
+		}
+	} else {
+		errs = append(errs, fmt.Errorf("list %s: %w", models.SourceMounts, err))
+	}
+
+	mounts, err = c.listUIMounts()
+	if err == nil {
+		if gates := gatesFromMounts(mounts, models.SourceUIMounts); len(gates) > 0 {
+			return gates, nil
+		}
+	} else {
+		errs = append(errs, fmt.Errorf("list %s: %w", models.SourceUIMounts, err))
+	}
+
+	if len(c.config.KnownGates) > 0 {
+		gates := make([]*models.Gate, 0, len(c.config.KnownGates))
+		for _, known := range c.config.KnownGates {
+			gate := known
+			gates = append(gates, &gate)
+		}
+		return gates, nil
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to discover gates: %w", stderrors.Join(errs...))
+	}
+	return nil, nil
+}
+
+// gatesFromMounts returns the GatePlane gates among the mounts
+func gatesFromMounts(mounts map[string]*vault.MountOutput, source models.GateSource) []*models.Gate {
 	var gates []*models.Gate
-	for path, auth := range auths {
-		if isGatePlanePlugin(auth.Type) {
-			gateType := models.PolicyGate
-			if strings.Contains(auth.Type, "okta") {
-				gateType = models.OktaGroupGate
-			}
-			// TODO: implement gate alias search here
+	for path, mount := range mounts {
+		if def, ok := models.DetectGateType(mount.Type); ok {
 			gate := &models.Gate{
 				Path:        strings.TrimSuffix(path, "/"),
-				Type:        gateType,
-				Description: auth.Description,
+				Type:        def.Type,
+				Description: mount.Description,
+				Source:      source,
 			}
 			gates = append(gates, gate)
 		}
 	}
-	return gates, nil
+	return gates
 }
 
-func (c *Client) CreateRequest(gate string, justification string) error {
+// listUIMounts lists the secret mounts the caller has any capability on.
+// Unlike 'sys/mounts', this endpoint is available to unprivileged tokens.
+func (c *Client) listUIMounts() (map[string]*vault.MountOutput, error) {
+	resp, err := c.client.Logical().Read("sys/internal/ui/mounts")
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Data == nil {
+		return nil, fmt.Errorf("no mounts returned")
+	}
+
+	secretMounts, ok := resp.Data["secret"].(map[string]interface{})
+	if !ok {
+		return map[string]*vault.MountOutput{}, nil
+	}
+
+	mounts := make(map[string]*vault.MountOutput, len(secretMounts))
+	for path, data := range secretMounts {
+		mount, err := decodeMount(data)
+		if err != nil {
+			continue // Skip mounts that cannot be decoded
+		}
+		mounts[path] = mount
+	}
+	return mounts, nil
+}
+
+// decodeMount converts generic mount data to a MountOutput
+func decodeMount(data interface{}) (*vault.MountOutput, error) {
+	mountJson, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var mount vault.MountOutput
+	if err := json.Unmarshal(mountJson, &mount); err != nil {
+		return nil, err
+	}
+	return &mount, nil
+}
+
+// CreateRequest creates an AccessRequest on the gate.
+// A zero ttl requests the gate's default access duration.
+func (c *Client) CreateRequest(gate string, justification string, ttl time.Duration) error {
 	path := fmt.Sprintf("%s/request", gate)
 	data := map[string]interface{}{
 		"justification": justification,
-		// "ttl": ttl,
+	}
+	if ttl > 0 {
+		data["ttl"] = int(ttl.Seconds())
 	}
 
 	_, err := c.client.Logical().Write(path, data)
@@ -133,6 +291,28 @@ func (c *Client) CreateRequest(gate string, justification string) error {
 	return nil
 }
 
+// GetGateLeaseConfig returns the default and maximum access duration of the gate,
+// served by the plugins under '<gate>/config/lease'
+func (c *Client) GetGateLeaseConfig(gate string) (*responses.ConfigLeaseResponse, error) {
+	resp, err := c.client.Logical().Read(fmt.Sprintf("%s/config/lease", gate))
+	if err != nil {
+		return nil, errors.WrapVaultError("read gate lease config", gate, err)
+	}
+	if resp == nil || resp.Data == nil {
+		return nil, errors.NewVaultError("read gate lease config", gate, fmt.Errorf("no lease configuration found"))
+	}
+
+	respJson, err := json.Marshal(resp.Data)
+	if err != nil {
+		return nil, err
+	}
+	var lease responses.ConfigLeaseResponse
+	if err := json.Unmarshal(respJson, &lease); err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
+
 func (c *Client) GetRequestStatus(gate string) (*models.Request, error) {
 	path := fmt.Sprintf("%s/request", gate)
 
@@ -146,43 +326,88 @@ func (c *Client) GetRequestStatus(gate string) (*models.Request, error) {
 		return nil, nil
 	}
 
-	respJson, err := json.Marshal(resp.Data)
+	var gate_ = models.Gate{
+		Path: gate,
+	}
+	c.describeGate(&gate_)
+
+	return decodeRequest(resp.Data, &gate_)
+}
+
+// describeGate fills in the type and description of a gate from its mount,
+// falling back to the gates known from the configuration
+func (c *Client) describeGate(gate *models.Gate) {
+	// Determine gate type by checking the plugin type
+	mount, err := c.lookupMount(gate.Path)
+	if err == nil {
+		if def, ok := models.DetectGateType(mount.Type); ok {
+			gate.Type = def.Type
+		}
+		gate.Description = mount.Description
+		return
+	}
+
+	for _, known := range c.config.KnownGates {
+		if known.Path == gate.Path {
+			gate.Type = known.Type
+			gate.Description = known.Description
+			return
+		}
+	}
+}
+
+// GetGateType returns the type of the gate mounted on the path
+func (c *Client) GetGateType(path string) (models.GateType, error) {
+	gate := &models.Gate{Path: strings.TrimSuffix(path, "/")}
+	c.describeGate(gate)
+	if gate.Type == "" {
+		return "", errors.NewVaultError("get gate type", path, errors.ErrGateNotFound)
+	}
+	return gate.Type, nil
+}
+
+// GetGateAccessConfig reads the access granted by the gate,
+// decoded according to its gate type
+func (c *Client) GetGateAccessConfig(gate string, gateType models.GateType) (interface{}, error) {
+	def, ok := gateType.Definition()
+	if !ok {
+		return nil, errors.NewVaultErrorf("get gate access", gate, "unsupported gate type %q", gateType)
+	}
+
+	resp, err := c.client.Logical().Read(fmt.Sprintf("%s/config/access", gate))
 	if err != nil {
-		fmt.Println("Error marshaling data:", err)
-		return nil, err
+		return nil, errors.WrapVaultError("get gate access", gate, err)
+	}
+	if resp == nil || resp.Data == nil {
+		return nil, errors.NewVaultErrorf("get gate access", gate, "no access configuration found")
 	}
 
-	var accessRequest responses.AccessRequestResponse
-	err = json.Unmarshal([]byte(respJson), &accessRequest)
+	return def.DecodeAccessConfig(resp.Data)
+}
+
+// decodeRequest converts the AccessRequest data returned by a gate to the combined Request model
+func decodeRequest(data map[string]interface{}, gate *models.Gate) (*models.Request, error) {
+	requestJson, err := json.Marshal(data)
 	if err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to marshal request data: %w", err)
 	}
 
-	var gate_ = models.Gate{
-		Path: gate,
+	var accessRequest responses.AccessRequestResponse
+	if err := json.Unmarshal(requestJson, &accessRequest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request data: %w", err)
 	}
-	// Determine gate type by checking the plugin type
-	// mounts, err := c.client.Sys().ListMounts()
-	mount, err := c.client.Sys().GetMount(gate)
-	if err == nil {
-		gate_.Description = mount.Description
-		// if auth, exists := mounts[path]; exists {
-		// 	if strings.Contains(auth.Type, "okta") {
-		// 		gate_.Type = models.OktaGroupGate
-		// 	} else {
-		// 		gate_.Type = models.PolicyGate
-		// 	}
-		// 	gate_.Description = auth.Description
-		// }
-	}
-
-	ret := &models.Request{
+
+	request := &models.Request{
 		AccessRequestResponse: &accessRequest,
-		Gate:                  &gate_,
+		Gate:                  gate,
+	}
+
+	// Set by the gate when the access was revoked or released before expiration
+	if revokedBy, ok := data["revoked_by"].(string); ok {
+		request.RevokedBy = revokedBy
 	}
 
-	return ret, nil
+	return request, nil
 }
 
 func (c *Client) ListAllRequestsForGate(path string) (map[string]*models.Request, error) {
@@ -210,18 +435,7 @@ func (c *Client) ListAllRequestsForGate(path string) (map[string]*models.Request
 		Path: strings.TrimSuffix(path, "/"),
 	}
 
-	// Determine gate type by checking the plugin type
-	mounts, err := c.client.Sys().ListMounts()
-	if err == nil {
-		if auth, exists := mounts[path+"/"]; exists {
-			if strings.Contains(auth.Type, "okta") {
-				gate.Type = models.OktaGroupGate
-			} else {
-				gate.Type = models.PolicyGate
-			}
-			gate.Description = auth.Description
-		}
-	}
+	c.describeGate(gate)
 
 	for requestorId, requestData := range requestsMap {
 		map_, ok := requestData.(map[string]interface{})
@@ -229,23 +443,9 @@ func (c *Client) ListAllRequestsForGate(path string) (map[string]*models.Request
 			continue // Skip if not a valid request map
 		}
 
-		// Convert the request data to JSON
-		requestJson, err := json.Marshal(map_)
+		request, err := decodeRequest(map_, gate)
 		if err != nil {
-			continue // Skip if we can't marshal
-		}
-
-		// Parse into AccessRequestResponse
-		var accessRequest responses.AccessRequestResponse
-		err = json.Unmarshal(requestJson, &accessRequest)
-		if err != nil {
-			continue // Skip if we can't unmarshal
-		}
-
-		// Create the combined Request model
-		request := &models.Request{
-			AccessRequestResponse: &accessRequest,
-			Gate:                  gate,
+			continue // Skip if we can't decode
 		}
 
 		requests[requestorId] = request
@@ -254,6 +454,18 @@ func (c *Client) ListAllRequestsForGate(path string) (map[string]*models.Request
 	return requests, nil
 }
 
+// CancelRequest deletes the caller's own AccessRequest on the gate
+func (c *Client) CancelRequest(gate string) error {
+	path := fmt.Sprintf("%s/request", gate)
+
+	_, err := c.client.Logical().Delete(path)
+	if err != nil {
+		return errors.WrapVaultError("cancel request", gate, err)
+	}
+
+	return nil
+}
+
 func (c *Client) ApproveRequest(gate string, requestorID string) error {
 	path := fmt.Sprintf("%s/approve/%s", gate, requestorID)
 	data := map[string]interface{}{}
@@ -266,6 +478,50 @@ func (c *Client) ApproveRequest(gate string, requestorID string) error {
 	return nil
 }
 
+// RejectRequest rejects the AccessRequest of the requestor on the gate, with an optional reason
+func (c *Client) RejectRequest(gate string, requestorID string, reason string) error {
+	path := fmt.Sprintf("%s/reject/%s", gate, requestorID)
+	data := map[string]interface{}{}
+	if reason != "" {
+		data["reason"] = reason
+	}
+
+	_, err := c.client.Logical().Write(path, data)
+	if err != nil {
+		return errors.WrapVaultError("reject request", gate, err)
+	}
+
+	return nil
+}
+
+// RevokeAccess revokes the access granted to the requestor on the gate, with an optional reason
+func (c *Client) RevokeAccess(gate string, requestorID string, reason string) error {
+	path := fmt.Sprintf("%s/revoke/%s", gate, requestorID)
+	data := map[string]interface{}{}
+	if reason != "" {
+		data["reason"] = reason
+	}
+
+	_, err := c.client.Logical().Write(path, data)
+	if err != nil {
+		return errors.WrapVaultError("revoke access", gate, err)
+	}
+
+	return nil
+}
+
+// ReleaseAccess hands the caller's own active access on the gate back before it expires
+func (c *Client) ReleaseAccess(gate string) error {
+	path := fmt.Sprintf("%s/release", gate)
+
+	_, err := c.client.Logical().Write(path, nil)
+	if err != nil {
+		return errors.WrapVaultError("release access", gate, err)
+	}
+
+	return nil
+}
+
 func (c *Client) GetSelf() (*models.Self, error) {
 	// Get token information using LookupSelf - this contains both entity and alias info
 	secret, err := c.client.Auth().Token().LookupSelf()
@@ -357,12 +613,6 @@ func (c *Client) ClaimAccess(gate string) (map[string]interface{}, error) {
 	return nil, nil
 }
 
-func isGatePlanePlugin(pluginType string) bool {
-	return strings.Contains(pluginType, "gateplane") &&
-		strings.Contains(pluginType, "policy-gate") ||
-		strings.Contains(pluginType, "okta-group-gate")
-}
-
 func (c *Client) GetPolicyGateAccessStruct(gate string) (*[]models.Access, error) {
 	path := fmt.Sprintf("%s/config/access", gate)
 
@@ -370,39 +620,62 @@ func (c *Client) GetPolicyGateAccessStruct(gate string) (*[]models.Access, error
 	if err != nil {
 		return nil, errors.WrapVaultError("policy-gate policy", gate, err)
 	}
+	if policies == nil || policies.Data == nil {
+		return nil, errors.NewVaultErrorf("policy-gate policy", gate, "no access configuration found")
+	}
+
+	// Only gates that grant policies have a 'policies' key
+	policyNames, _ := policies.Data["policies"].([]interface{})
+
+	// A policy that cannot be read or parsed is reported, without hiding the rest
 	var policiesParsed []*models.PolicyACL
-	for _, p := range policies.Data["policies"].([]interface{}) {
-		parsed, err := c.GetPolicy(p.(string))
+	for _, p := range policyNames {
+		name := fmt.Sprint(p)
+		parsed, err := c.GetPolicy(name)
 		if err != nil {
-			continue
+			parsed = &models.PolicyACL{Name: name, Error: err.Error()}
 		}
 		policiesParsed = append(policiesParsed, parsed)
 	}
 
-	mounts, err := c.client.Sys().ListMounts()
+	mounts, err := c.listMounts()
 	if err != nil {
 		return nil, errors.WrapVaultError("list mounts", "/sys/mounts", err)
 	}
 
-	var ret []models.Access
+	// Auth mounts are matched as 'auth/<path>', as they appear in policies.
+	// Listing them may be denied, in which case their paths stay unmatched.
+	allMounts := make(map[string]*vault.MountOutput, len(mounts))
+	for mountPath, mount := range mounts {
+		allMounts[mountPath] = mount
+	}
+	if authMounts, err := c.listAuthMounts(); err == nil {
+		for mountPath, mount := range authMounts {
+			allMounts["auth/"+mountPath] = mount
+		}
+	}
 
+	var ret []models.Access
 	for _, policy := range policiesParsed {
 		access := models.Access{
 			Policy: policy.Name,
-			Types:  map[string]models.AccessBlock{},
+			Mounts: map[string]models.AccessBlock{},
+			Error:  policy.Error,
 		}
-		for mountPath, mount := range mounts {
-			var aBlock models.AccessBlock
-
-			for _, path := range policy.Parsed.Paths {
-
-				if strings.HasPrefix(path.Path, mountPath) {
-					// fmt.Println(mountPath, mount.Type, path.Path)
-					aBlock.PathBlock = append(aBlock.PathBlock, path)
+		for _, path := range policy.Parsed.Paths {
+			matched := false
+			for _, match := range matchMounts(path.Path, allMounts) {
+				mount := allMounts[match.mountPath]
+				block, ok := access.Mounts[match.mountPath]
+				if !ok {
+					block = newAccessBlock(mount)
 				}
+				block.Paths = append(block.Paths, newMountPath(path, mount, match.relativePath))
+				access.Mounts[match.mountPath] = block
+				matched = true
 			}
-			if len(aBlock.PathBlock) != 0 {
-				access.Types[mount.Type] = aBlock
+			if !matched {
+				access.Unmatched = append(access.Unmatched, path)
 			}
 		}
 		ret = append(ret, access)
@@ -411,7 +684,8 @@ func (c *Client) GetPolicyGateAccessStruct(gate string) (*[]models.Access, error
 	return &ret, nil
 }
 
-// GetPolicy fetches a Vault policy by name and parses it from HCL to a JSON-serializable object
+// GetPolicy fetches a Vault policy by name and parses it from HCL to a JSON-serializable object.
+// If the rules cannot be parsed, the returned policy has its Error set along with the error.
 func (c *Client) GetPolicy(policyName string) (*models.PolicyACL, error) {
 	// Fetch the policy from Vault
 	path := fmt.Sprintf("sys/policy/%s", policyName)
@@ -437,14 +711,10 @@ func (c *Client) GetPolicy(policyName string) (*models.PolicyACL, error) {
 		Rules: rules,
 	}
 
-	// Decode HCL from string using strings.NewReader
-	err = hclsimple.Decode("policy.hcl", []byte(rules), nil, &policy.Parsed)
-	if err != nil {
-		log.Fatal(err)
-	}
-
+	policy.Parsed, err = ParsePolicy(rules)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse policy HCL: %w", err)
+		policy.Error = err.Error()
+		return policy, errors.WrapVaultError("parse policy", policyName, err)
 	}
 
 	return policy, nil
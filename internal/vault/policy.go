@@ -0,0 +1,120 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package vault
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+)
+
+// pathRulesHCL mirrors the attributes of a 'path' block in a Vault ACL policy.
+// Vault parses policies with HCL1, which also accepts the JSON policy format.
+type pathRulesHCL struct {
+	Policy             string                   `hcl:"policy"`
+	Capabilities       []string                 `hcl:"capabilities"`
+	AllowedParameters  map[string][]interface{} `hcl:"allowed_parameters"`
+	DeniedParameters   map[string][]interface{} `hcl:"denied_parameters"`
+	RequiredParameters []string                 `hcl:"required_parameters"`
+	MinWrappingTTL     interface{}              `hcl:"min_wrapping_ttl"`
+	MaxWrappingTTL     interface{}              `hcl:"max_wrapping_ttl"`
+}
+
+// deprecatedPolicyCapabilities maps the deprecated 'policy = "<level>"' form to capabilities,
+// the same way Vault does
+var deprecatedPolicyCapabilities = map[string][]string{
+	"deny":  {"deny"},
+	"read":  {"read", "list"},
+	"write": {"read", "list", "create", "update", "delete"},
+	"sudo":  {"read", "list", "create", "update", "delete", "sudo"},
+}
+
+// ParsePolicy parses the rules of a Vault ACL policy (HCL or JSON)
+func ParsePolicy(rules string) (models.Policy, error) {
+	var policy models.Policy
+
+	root, err := hcl.ParseString(rules)
+	if err != nil {
+		return policy, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	list, ok := root.Node.(*ast.ObjectList)
+	if !ok {
+		return policy, fmt.Errorf("failed to parse policy: does not contain a root object")
+	}
+
+	for _, item := range list.Filter("path").Items {
+		if len(item.Keys) == 0 {
+			return policy, fmt.Errorf("path block without a path at %s", item.Pos())
+		}
+		path, ok := item.Keys[0].Token.Value().(string)
+		if !ok {
+			return policy, fmt.Errorf("invalid path at %s", item.Keys[0].Pos())
+		}
+
+		var rules pathRulesHCL
+		if err := hcl.DecodeObject(&rules, item.Val); err != nil {
+			return policy, fmt.Errorf("failed to parse path %q: %w", path, err)
+		}
+
+		pathBlock, err := newPathBlock(path, rules)
+		if err != nil {
+			return policy, fmt.Errorf("failed to parse path %q: %w", path, err)
+		}
+		policy.Paths = append(policy.Paths, pathBlock)
+	}
+
+	return policy, nil
+}
+
+func newPathBlock(path string, rules pathRulesHCL) (models.PathBlock, error) {
+	pathBlock := models.PathBlock{
+		Path:               path,
+		Capabilities:       rules.Capabilities,
+		AllowedParameters:  rules.AllowedParameters,
+		DeniedParameters:   rules.DeniedParameters,
+		RequiredParameters: rules.RequiredParameters,
+		MinWrappingTTL:     ttlString(rules.MinWrappingTTL),
+		MaxWrappingTTL:     ttlString(rules.MaxWrappingTTL),
+		Policy:             rules.Policy,
+	}
+
+	if rules.Policy != "" {
+		capabilities, ok := deprecatedPolicyCapabilities[rules.Policy]
+		if !ok {
+			return pathBlock, fmt.Errorf("invalid policy level %q", rules.Policy)
+		}
+		for _, capability := range capabilities {
+			if !slices.Contains(pathBlock.Capabilities, capability) {
+				pathBlock.Capabilities = append(pathBlock.Capabilities, capability)
+			}
+		}
+	}
+
+	return pathBlock, nil
+}
+
+// ttlString normalizes wrapping TTLs, which can be given as seconds or duration strings
+func ttlString(ttl interface{}) string {
+	switch v := ttl.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int, int64:
+		return fmt.Sprintf("%ds", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
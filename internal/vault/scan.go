@@ -0,0 +1,104 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package vault
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/gateplane-io/client-cli/pkg/models"
+	vault "github.com/hashicorp/vault/api"
+)
+
+// DefaultScanConcurrency is the number of gates scanned in parallel if not configured
+const DefaultScanConcurrency = 8
+
+// ScanOptions selects what is read from each gate during a scan
+type ScanOptions struct {
+	// Number of gates scanned in parallel
+	Concurrency int
+	// Read the caller's own request on each gate
+	Own bool
+	// List all requests on each gate (only allowed for approvers)
+	List bool
+}
+
+// GateScan is the result of scanning a single gate
+type GateScan struct {
+	Gate *models.Gate
+	// The caller's own request, nil if there is none
+	Own    *models.Request
+	OwnErr error
+	// All requests of the gate, nil if they could not be listed
+	Requests map[string]*models.Request
+	ListErr  error
+}
+
+// ScanGates reads the requests of all gates using a bounded pool of workers.
+// The results are returned in the same order as the gates.
+func (c *Client) ScanGates(gates []*models.Gate, opts ScanOptions) []*GateScan {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultScanConcurrency
+	}
+	if concurrency > len(gates) {
+		concurrency = len(gates)
+	}
+
+	// Warm the mount cache once instead of racing for it in every worker
+	_, _ = c.listMounts()
+
+	results := make([]*GateScan, len(gates))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = c.scanGate(gates[i], opts)
+			}
+		}()
+	}
+
+	for i := range gates {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+func (c *Client) scanGate(gate *models.Gate, opts ScanOptions) *GateScan {
+	scan := &GateScan{Gate: gate}
+
+	if opts.Own {
+		scan.Own, scan.OwnErr = c.GetRequestStatus(gate.Path)
+	}
+	if opts.List {
+		scan.Requests, scan.ListErr = c.ListAllRequestsForGate(gate.Path)
+	}
+
+	return scan
+}
+
+// IsPermissionDenied reports whether Vault denied the operation,
+// e.g. when listing the requests of a gate the caller does not approve
+func IsPermissionDenied(err error) bool {
+	var respErr *vault.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// TokenHelper runs an external Vault token helper, following the protocol of the vault CLI:
+// the helper is executed with 'get', 'store' or 'erase' and VAULT_ADDR set to the Vault address.
+// 'get' prints the token, 'store' reads it from stdin.
+type TokenHelper struct {
+	Path string
+}
+
+// Get returns the token stored by the helper for the Vault address, empty if there is none
+func (h *TokenHelper) Get(address string) (string, error) {
+	out, err := h.run("get", address, "")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Store hands the token of the Vault address to the helper
+func (h *TokenHelper) Store(address, token string) error {
+	_, err := h.run("store", address, token)
+	return err
+}
+
+// Erase removes the token of the Vault address from the helper
+func (h *TokenHelper) Erase(address string) error {
+	_, err := h.run("erase", address, "")
+	return err
+}
+
+func (h *TokenHelper) run(operation, address, input string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(h.Path, operation)
+	cmd.Env = append(os.Environ(), "VAULT_ADDR="+address)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("token helper %s %s: %w: %s", h.Path, operation, err, msg)
+		}
+		return "", fmt.Errorf("token helper %s %s: %w", h.Path, operation, err)
+	}
+	return stdout.String(), nil
+}
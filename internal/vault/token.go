@@ -0,0 +1,108 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gateplane-io/client-cli/pkg/errors"
+	"github.com/gateplane-io/client-cli/pkg/models"
+	vault "github.com/hashicorp/vault/api"
+)
+
+// MinAutoRenewThreshold is the remaining TTL below which a renewable token is always renewed
+const MinAutoRenewThreshold = 5 * time.Minute
+
+// LookupToken returns the lifecycle information of the token in use
+func (c *Client) LookupToken() (*models.TokenInfo, error) {
+	secret, err := c.client.Auth().Token().LookupSelf()
+	if err != nil {
+		return nil, errors.WrapVaultError("lookup self token", "", err)
+	}
+	return decodeTokenInfo(secret)
+}
+
+// RenewToken extends the token in use by the increment, or by its default TTL if the increment is zero.
+// Vault may grant less than the increment, e.g. when the token reaches its max TTL.
+func (c *Client) RenewToken(increment time.Duration) (*models.TokenInfo, error) {
+	if _, err := c.client.Auth().Token().RenewSelf(int(increment.Seconds())); err != nil {
+		return nil, errors.WrapVaultError("renew token", "", err)
+	}
+	return c.LookupToken()
+}
+
+// RenewTokenIfExpiring renews the token in use when it is renewable and a third of its TTL
+// (at least MinAutoRenewThreshold) remains, the way Vault's lifetime watcher does.
+// Returns the renewed token, or nil if no renewal was needed.
+func (c *Client) RenewTokenIfExpiring() (*models.TokenInfo, error) {
+	info, err := c.LookupToken()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Renewable || info.TTL <= 0 {
+		return nil, nil
+	}
+
+	threshold := time.Duration(info.CreationTTL) * time.Second / 3
+	if threshold < MinAutoRenewThreshold {
+		threshold = MinAutoRenewThreshold
+	}
+	if info.TTLDuration() > threshold {
+		return nil, nil
+	}
+
+	return c.RenewToken(0)
+}
+
+// decodeTokenInfo extracts the token lifecycle from a token lookup response
+func decodeTokenInfo(secret *vault.Secret) (*models.TokenInfo, error) {
+	if secret == nil || secret.Data == nil {
+		return nil, errors.NewVaultError("lookup self token", "", fmt.Errorf("no token data found"))
+	}
+
+	info := &models.TokenInfo{}
+	info.DisplayName, _ = secret.Data["display_name"].(string)
+	info.Accessor, _ = secret.Data["accessor"].(string)
+	info.EntityID, _ = secret.Data["entity_id"].(string)
+	info.Orphan, _ = secret.Data["orphan"].(bool)
+	info.Renewable, _ = secret.Data["renewable"].(bool)
+
+	if policies, ok := secret.Data["policies"].([]interface{}); ok {
+		for _, policy := range policies {
+			info.Policies = append(info.Policies, fmt.Sprint(policy))
+		}
+	}
+
+	if ttl, ok := secret.Data["ttl"].(json.Number); ok {
+		info.TTL, _ = ttl.Int64()
+	}
+	if creationTTL, ok := secret.Data["creation_ttl"].(json.Number); ok {
+		info.CreationTTL, _ = creationTTL.Int64()
+	}
+
+	if expireTime, ok := secret.Data["expire_time"].(string); ok && expireTime != "" {
+		if parsed, err := time.Parse(time.RFC3339Nano, expireTime); err == nil {
+			info.ExpireTime = &parsed
+		}
+	}
+
+	return info, nil
+}
+
+// RevokeToken revokes the token in use (and its children) in Vault
+func (c *Client) RevokeToken() error {
+	if err := c.client.Auth().Token().RevokeSelf(""); err != nil {
+		return errors.WrapVaultError("revoke token", "", err)
+	}
+	return nil
+}
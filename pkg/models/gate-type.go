@@ -0,0 +1,198 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// ClaimField is a single piece of information of a claim response, as shown to the user
+type ClaimField struct {
+	Name  string
+	Value string
+}
+
+// GateTypeDefinition describes how the CLI handles a type of GatePlane gate.
+// New GatePlane plugins are supported by registering a definition with RegisterGateType.
+type GateTypeDefinition struct {
+	Type GateType
+	// Alternative names accepted on the command line (e.g. 'policy' for 'policy-gate')
+	Aliases []string
+	// Detect reports whether a Vault mount type is a gate of this type
+	Detect func(mountType string) bool
+	// DecodeAccessConfig decodes the '<gate>/config/access' data of the gate
+	DecodeAccessConfig func(data map[string]interface{}) (interface{}, error)
+	// DescribeAccess summarizes the access granted by the gate, from its decoded access config
+	DescribeAccess func(accessConfig interface{}) string
+	// GrantedPolicies returns the Vault policies granted by the gate, if any
+	GrantedPolicies func(accessConfig interface{}) []string
+	// RenderClaim returns the fields of a claim response shown to the user
+	RenderClaim func(claim map[string]interface{}) []ClaimField
+}
+
+var (
+	gateTypesMu sync.RWMutex
+	gateTypes   []*GateTypeDefinition
+)
+
+// RegisterGateType adds a gate type to the registry, replacing any definition of the same type
+func RegisterGateType(def *GateTypeDefinition) {
+	gateTypesMu.Lock()
+	defer gateTypesMu.Unlock()
+
+	for i, existing := range gateTypes {
+		if existing.Type == def.Type {
+			gateTypes[i] = def
+			return
+		}
+	}
+	gateTypes = append(gateTypes, def)
+}
+
+// LookupGateType finds a gate type by its name or one of its aliases
+func LookupGateType(name string) (*GateTypeDefinition, bool) {
+	gateTypesMu.RLock()
+	defer gateTypesMu.RUnlock()
+
+	for _, def := range gateTypes {
+		if string(def.Type) == name {
+			return def, true
+		}
+		for _, alias := range def.Aliases {
+			if alias == name {
+				return def, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// DetectGateType finds the gate type of a Vault mount type.
+// Returns false if the mount is not a GatePlane gate.
+func DetectGateType(mountType string) (*GateTypeDefinition, bool) {
+	gateTypesMu.RLock()
+	defer gateTypesMu.RUnlock()
+
+	for _, def := range gateTypes {
+		if def.Detect != nil && def.Detect(mountType) {
+			return def, true
+		}
+	}
+	return nil, false
+}
+
+// GateTypeNames returns the names of all registered gate types
+func GateTypeNames() []string {
+	gateTypesMu.RLock()
+	defer gateTypesMu.RUnlock()
+
+	names := make([]string, len(gateTypes))
+	for i, def := range gateTypes {
+		names[i] = string(def.Type)
+	}
+	return names
+}
+
+// Definition returns the registered definition of the gate type
+func (t GateType) Definition() (*GateTypeDefinition, bool) {
+	return LookupGateType(string(t))
+}
+
+// decodeInto converts generic response data to a typed response
+func decodeInto[T any](data map[string]interface{}) (interface{}, error) {
+	var decoded T
+	dataJson, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(dataJson, &decoded); err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+// stringList converts a claim response value to a list of strings
+func stringList(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprint(item))
+	}
+	return list
+}
+
+func init() {
+	RegisterGateType(&GateTypeDefinition{
+		Type:    PolicyGate,
+		Aliases: []string{"policy"},
+		Detect: func(mountType string) bool {
+			return strings.Contains(mountType, "gateplane") && strings.Contains(mountType, "policy-gate")
+		},
+		DecodeAccessConfig: decodeInto[responses.ConfigAccessPolicyGate],
+		DescribeAccess: func(accessConfig interface{}) string {
+			access, ok := accessConfig.(*responses.ConfigAccessPolicyGate)
+			if !ok || len(access.Policies) == 0 {
+				return "No policies"
+			}
+			return "Policies: " + strings.Join(access.Policies, ", ")
+		},
+		GrantedPolicies: func(accessConfig interface{}) []string {
+			if access, ok := accessConfig.(*responses.ConfigAccessPolicyGate); ok {
+				return access.Policies
+			}
+			return nil
+		},
+		RenderClaim: func(claim map[string]interface{}) []ClaimField {
+			var fields []ClaimField
+			if policies := stringList(claim["new_policies"]); len(policies) > 0 {
+				fields = append(fields, ClaimField{Name: "Entity Policies", Value: strings.Join(policies, ", ")})
+			}
+			return fields
+		},
+	})
+
+	RegisterGateType(&GateTypeDefinition{
+		Type:    OktaGroupGate,
+		Aliases: []string{"okta"},
+		Detect: func(mountType string) bool {
+			return strings.Contains(mountType, "okta-group-gate")
+		},
+		DecodeAccessConfig: decodeInto[responses.ConfigAccessOktaGroupGate],
+		DescribeAccess: func(accessConfig interface{}) string {
+			access, ok := accessConfig.(*responses.ConfigAccessOktaGroupGate)
+			if !ok || access.GroupID == "" {
+				return "No Okta group"
+			}
+			if access.GroupName == "" {
+				return fmt.Sprintf("Okta group: %s", access.GroupID)
+			}
+			return fmt.Sprintf("Okta group: %s (%s)", access.GroupName, access.GroupID)
+		},
+		RenderClaim: func(claim map[string]interface{}) []ClaimField {
+			var fields []ClaimField
+			if groupID, ok := claim["okta_group_id"].(string); ok {
+				fields = append(fields, ClaimField{Name: "Okta Group", Value: groupID})
+			}
+			if userID, ok := claim["okta_user_id"].(string); ok {
+				fields = append(fields, ClaimField{Name: "Okta User", Value: userID})
+			}
+			return fields
+		},
+	})
+}
@@ -19,12 +19,26 @@ const (
 	OktaGroupGate GateType = "okta-group-gate"
 )
 
+// GateSource describes where a gate was discovered from
+type GateSource string
+
+// Gate discovery sources, in order of preference
+const (
+	SourceMounts   GateSource = "sys/mounts"
+	SourceUIMounts GateSource = "sys/internal/ui/mounts"
+	SourceConfig   GateSource = "config"
+	SourceProject  GateSource = "project"
+	SourceCatalog  GateSource = "catalog"
+)
+
 // Gate represents a GatePlane gate configuration
 type Gate struct {
-	Path        string   `json:"path" yaml:"path"`
-	Type        GateType `json:"type" yaml:"type"`
-	Alias       string   `json:"alias,omitempty" yaml:"alias,omitempty"`
-	Description string   `json:"description" yaml:"description,omitempty"`
+	Path        string     `json:"path" yaml:"path"`
+	Type        GateType   `json:"type" yaml:"type"`
+	Alias       string     `json:"alias,omitempty" yaml:"alias,omitempty"`
+	Description string     `json:"description" yaml:"description,omitempty"`
+	Tags        []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Source      GateSource `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
 // EntityAlias represents a Vault entity alias
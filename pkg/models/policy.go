@@ -10,9 +10,9 @@
 
 package models
 
-// Wrap in a struct for hclsimple
+// Policy holds the 'path' blocks of a parsed Vault ACL policy
 type Policy struct {
-	Paths []PathBlock `hcl:"path,block"`
+	Paths []PathBlock `json:"paths"`
 }
 
 // PolicyACL represents a Vault ACL policy
@@ -20,20 +20,49 @@ type PolicyACL struct {
 	Name   string `json:"name"`
 	Rules  string `json:"rules"` // Original HCL rules
 	Parsed Policy `json:"parsed"`
+	Error  string `json:"error,omitempty"` // Set if the policy could not be read or parsed
 }
 
 type PathBlock struct {
-	Path         string   `hcl:"path,label" json:"path"`           // the label in path "secret/*"
-	Capabilities []string `hcl:"capabilities" json:"capabilities"` // the capabilities array
+	Path               string                   `json:"path"`                          // the label in path "secret/*"
+	Capabilities       []string                 `json:"capabilities"`                  // the capabilities array
+	AllowedParameters  map[string][]interface{} `json:"allowed_parameters,omitempty"`  // parameter -> allowed values (empty allows any)
+	DeniedParameters   map[string][]interface{} `json:"denied_parameters,omitempty"`   // parameter -> denied values (empty denies any)
+	RequiredParameters []string                 `json:"required_parameters,omitempty"` // parameters that must be present
+	MinWrappingTTL     string                   `json:"min_wrapping_ttl,omitempty"`
+	MaxWrappingTTL     string                   `json:"max_wrapping_ttl,omitempty"`
+	Policy             string                   `json:"policy,omitempty"` // deprecated 'policy = "<level>"' form, expanded into Capabilities
 }
 
+// HasRestrictions reports whether the path block restricts parameters or response wrapping
+func (p PathBlock) HasRestrictions() bool {
+	return len(p.AllowedParameters) > 0 || len(p.DeniedParameters) > 0 || len(p.RequiredParameters) > 0 ||
+		p.MinWrappingTTL != "" || p.MaxWrappingTTL != ""
+}
+
+// MountPath is a path block of a policy that falls under a mount
+type MountPath struct {
+	PathBlock
+	// The path as seen by KV users (e.g. 'app/*' for 'secret/data/app/*'), only set for KV mounts
+	LogicalPath string `json:"logical_path,omitempty"`
+	// The KV v2 endpoint of the path (data, metadata, delete, undelete, destroy, subkeys, config)
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// AccessBlock is the access a policy grants on a single mount.
 // Used to send to GatePlane Services
 type AccessBlock struct {
-	PathBlock   []PathBlock `json:"paths"`
+	MountType   string      `json:"type"`
+	Version     string      `json:"version,omitempty"` // KV version for 'kv' mounts, plugin version otherwise
 	Description string      `json:"description"`
+	Paths       []MountPath `json:"paths"`
 }
 
+// Access is the access a policy grants, grouped by mount path (e.g. 'secret/', 'auth/userpass/')
 type Access struct {
 	Policy string                 `json:"policy"`
-	Types  map[string]AccessBlock `json:"accessTypes"`
+	Mounts map[string]AccessBlock `json:"mounts"`
+	// Paths of the policy that do not fall under any known mount
+	Unmatched []PathBlock `json:"unmatched,omitempty"`
+	Error     string      `json:"error,omitempty"` // Set if the policy could not be read or parsed
 }
@@ -17,4 +17,6 @@ import (
 type Request struct {
 	*responses.AccessRequestResponse
 	*Gate
+	// The entity that revoked (or released) the access, if any
+	RevokedBy string `json:"revoked_by,omitempty" yaml:"revoked_by,omitempty"`
 }
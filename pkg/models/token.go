@@ -0,0 +1,38 @@
+// Copyright (C) 2026 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package models
+
+import "time"
+
+// TokenInfo describes the lifecycle of the Vault token in use
+type TokenInfo struct {
+	DisplayName string   `json:"display_name" yaml:"display_name"`
+	Accessor    string   `json:"accessor" yaml:"accessor"`
+	EntityID    string   `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	Policies    []string `json:"policies" yaml:"policies"`
+	// Remaining time to live in seconds, 0 for tokens that never expire
+	TTL int64 `json:"ttl" yaml:"ttl"`
+	// Time to live the token was created (or last renewed) with, in seconds
+	CreationTTL int64      `json:"creation_ttl" yaml:"creation_ttl"`
+	ExpireTime  *time.Time `json:"expire_time,omitempty" yaml:"expire_time,omitempty"`
+	Renewable   bool       `json:"renewable" yaml:"renewable"`
+	Orphan      bool       `json:"orphan" yaml:"orphan"`
+}
+
+// TTLDuration returns the remaining time to live of the token
+func (t *TokenInfo) TTLDuration() time.Duration {
+	return time.Duration(t.TTL) * time.Second
+}
+
+// Expires reports whether the token has a limited lifetime (root tokens do not)
+func (t *TokenInfo) Expires() bool {
+	return t.TTL > 0 || t.ExpireTime != nil
+}